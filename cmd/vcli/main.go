@@ -3,7 +3,9 @@ package main
 import (
 	"flag"
 	"fmt"
+	"os"
 
+	"github.com/ewilliams0305/VC4-CLI/pkg/cli"
 	"github.com/ewilliams0305/VC4-CLI/pkg/tui"
 )
 
@@ -12,6 +14,24 @@ func main() {
 	tui.InitFlags()
 	flag.Parse()
 
+	if cli.IsCommand(flag.Args()) {
+		server, err := tui.NewServer()
+		if err == nil && !cli.IsOffline(flag.Args()) {
+			if readOnlyErr := tui.DetectReadOnly(server); readOnlyErr != nil {
+				fmt.Fprintf(os.Stderr, "⚠  %v\n", readOnlyErr)
+			}
+		}
+		if err == nil {
+			err = cli.Execute(server, flag.Args())
+			tui.FlushAudit()
+		}
+		if err != nil {
+			fmt.Printf("vcli: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	fmt.Printf("\n\nCLI Started with host flag: %s %s\n\n", tui.Hostname, tui.Token)
 	tui.Run()
 }
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ewilliams0305/VC4-CLI/pkg/tui"
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+// Queries the audit log, the newest entries are printed last.
+//
+// vcli audit --target ROOM1 --since 7d
+// vcli audit --op StopRoom --user ewilliams --failed
+func queryAudit(args []string) error {
+	flags := flag.NewFlagSet("audit", flag.ContinueOnError)
+	file := flags.String("file", "", "the audit log to read, defaults to the audit log of the profile")
+	since := flags.String("since", "", "only entries newer than this age, 24h, 7d")
+	user := flags.String("user", "", "only entries by this OS user")
+	host := flags.String("host", "", "only entries sent to this appliance")
+	target := flags.String("target", "", "only entries changing this room, program, or token")
+	op := flags.String("op", "", "only operations containing this text, StopRoom, Program")
+	failed := flags.Bool("failed", false, "only operations that failed")
+	limit := flags.Int("limit", 50, "the number of entries printed, 0 prints every entry")
+	asJson := flags.Bool("json", false, "prints the matching entries as JSON lines")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	age, err := parseAge(*since)
+	if err != nil {
+		return err
+	}
+
+	path := *file
+	if len(path) == 0 {
+		path = tui.AuditFile
+	}
+	entries, err := vc.ReadAuditLog(path)
+	if err != nil {
+		return err
+	}
+
+	matches := make([]vc.AuditEntry, 0)
+	for _, e := range entries {
+		if age > 0 && e.Time.Before(time.Now().Add(-age)) {
+			continue
+		}
+		if len(*user) > 0 && !strings.EqualFold(e.User, *user) {
+			continue
+		}
+		if len(*host) > 0 && !strings.EqualFold(e.Host, *host) {
+			continue
+		}
+		if len(*target) > 0 && !hasTarget(e, *target) {
+			continue
+		}
+		if len(*op) > 0 && !strings.Contains(strings.ToLower(e.Operation), strings.ToLower(*op)) {
+			continue
+		}
+		if *failed && e.Outcome == vc.AuditOk {
+			continue
+		}
+		matches = append(matches, e)
+	}
+
+	if *limit > 0 && len(matches) > *limit {
+		matches = matches[len(matches)-*limit:]
+	}
+
+	if *asJson {
+		encoder := json.NewEncoder(os.Stdout)
+		for _, e := range matches {
+			if err := encoder.Encode(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(matches) == 0 {
+		fmt.Printf("\nno audit entries in %s match the filters\n\n", path)
+		return nil
+	}
+
+	fmt.Printf("\n%d of %d audit entries in %s\n\n", len(matches), len(entries), path)
+	w := newTable()
+	fmt.Fprintln(w, "  TIME\tUSER\tHOST\tOPERATION\tTARGETS\tPARAMS\tOUTCOME\tSTATUS")
+	for _, e := range matches {
+		outcome := "✅"
+		if e.Outcome != vc.AuditOk {
+			outcome = "❌ " + e.Outcome
+		}
+		status := e.StatusInfo
+		if len(e.Error) > 0 {
+			status = e.Error
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			e.Time.Local().Format(time.DateTime), e.User, e.Host, e.Operation, strings.Join(e.Targets, ","), auditParams(e), outcome, status)
+	}
+	w.Flush()
+	fmt.Println()
+	return nil
+}
+
+func hasTarget(e vc.AuditEntry, target string) bool {
+	for _, t := range e.Targets {
+		if strings.EqualFold(t, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the params and uploaded files of the entry as a short key=value list.
+func auditParams(e vc.AuditEntry) string {
+	params := make([]string, 0, len(e.Params)+len(e.Files))
+	for k, v := range e.Params {
+		params = append(params, k+"="+v)
+	}
+	for _, f := range e.Files {
+		params = append(params, f.Field+"="+f.FileName)
+	}
+	slices.Sort(params)
+	return strings.Join(params, " ")
+}
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"time"
+
+	"github.com/ewilliams0305/VC4-CLI/pkg/config"
+	"github.com/ewilliams0305/VC4-CLI/pkg/tui"
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+	"github.com/skip2/go-qrcode"
+)
+
+// Creates a token using the local API of the appliance and prints a profile for a remote machine.
+// Local connections don't need a token, run the command on the appliance over SSH.
+//
+// vcli bootstrap --name site-a --qr
+// vcli bootstrap --name site-a --read-only --out site-a.json
+func bootstrap(args []string) error {
+	flags := flag.NewFlagSet("bootstrap", flag.ContinueOnError)
+	name := flags.String("name", "", "the name of the profile, defaults to the hostname of the appliance")
+	host := flags.String("host", "", "the IP or hostname the remote machine connects to, defaults to the first IPv4 address of the appliance")
+	readOnly := flags.Bool("read-only", false, "creates a read only token")
+	out := flags.String("out", "", "writes the profile to a configuration file, use the file as $VCLI_CONFIG or copy the profile into your own")
+	qr := flags.Bool("qr", false, "renders the profile as a QR code")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if len(tui.Token) > 0 {
+		return fmt.Errorf("BOOTSTRAP USES THE LOCAL API, RUN vcli bootstrap ON THE APPLIANCE WITHOUT A TOKEN")
+	}
+
+	hostname, _ := os.Hostname()
+	if len(*name) == 0 {
+		*name = hostname
+	}
+	if len(*host) == 0 {
+		*host = localAddress(hostname)
+	}
+
+	// The file is created before the token so a token isn't left on the appliance when the profile can't be written.
+	var file *os.File
+	keep := false
+	if len(*out) > 0 {
+		f, err := os.OpenFile(*out, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			return err
+		}
+		file = f
+		defer func() {
+			file.Close()
+			if !keep {
+				os.Remove(file.Name())
+			}
+		}()
+	}
+
+	fingerprint, err := vc.CertFingerprint(*host)
+	if err != nil {
+		fmt.Printf("\n⚠  %v\n   the profile is written without a cert_fingerprint\n", err)
+	}
+
+	token, err := server.CreateToken(*readOnly, bootstrapDescription())
+	if err != nil {
+		return err
+	}
+	if len(token.Token) == 0 {
+		return fmt.Errorf("THE APPLIANCE DID NOT RETURN THE NEW TOKEN")
+	}
+
+	profile := config.Profile{Host: *host, Token: token.Token, CertFingerprint: fingerprint}
+	snippet, err := json.MarshalIndent(map[string]config.Profile{*name: profile}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	level := "read write"
+	if *readOnly {
+		level = "read only"
+	}
+	fmt.Printf("\n✅ created %s token %s\n\n", level, token.Description)
+	fmt.Printf("add the profile to the profiles of vcli/config.json in the user config directory of your machine, or the file named by $%s, and run vcli -profile %s\n\n%s\n\n", config.ConfigEnv, *name, snippet)
+
+	if file != nil {
+		if err := writeBootstrapConfig(file, *name, profile); err != nil {
+			return err
+		}
+		keep = true
+		fmt.Printf("✅ wrote the profile to %s, the file contains the token\n\n", *out)
+	}
+
+	if *qr {
+		code, err := qrcode.New(string(snippet), qrcode.Medium)
+		if err != nil {
+			return err
+		}
+		fmt.Println(code.ToSmallString(false))
+	}
+	return nil
+}
+
+// The description of every bootstrap token, "vcli bootstrap root 2024-05-01".
+func bootstrapDescription() string {
+	name := "unknown"
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	return fmt.Sprintf("vcli bootstrap %s %s", name, time.Now().Format(time.DateOnly))
+}
+
+// Returns the first IPv4 address of the appliance that isn't a loopback address, the hostname when none is found.
+func localAddress(hostname string) string {
+	addresses, err := net.InterfaceAddrs()
+	if err != nil {
+		return hostname
+	}
+	for _, a := range addresses {
+		if ip, ok := a.(*net.IPNet); ok && !ip.IP.IsLoopback() && ip.IP.To4() != nil {
+			return ip.IP.String()
+		}
+	}
+	return hostname
+}
+
+// Writes a configuration file containing the profile, the file is created by bootstrap and never overwritten.
+func writeBootstrapConfig(file *os.File, name string, profile config.Profile) error {
+	data, err := json.MarshalIndent(config.Config{Profiles: map[string]config.Profile{name: profile}}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,298 @@
+package cli
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+// A vcli sub command executed without launching the TUI, `vcli doctor` or `vcli programs prune`.
+// Commands with child commands are groups, the first argument selects the child.
+type command struct {
+	name        string
+	description string
+	run         func(args []string) error
+	commands    []command
+	// The command never sends requests to the appliance, the token isn't looked up before it runs.
+	offline bool
+}
+
+var server vc.VirtualControl
+
+// Shared by every prompt, a reader per prompt would drop input buffered by the previous prompt.
+var stdin = bufio.NewReader(os.Stdin)
+
+var commands = []command{
+	{
+		name:        "doctor",
+		description: "checks the appliance for rooms and programs that need attention",
+		run:         doctor,
+	},
+	{
+		name:        "dev",
+		description: "watches a program file and redeploys it to a dev room on each build",
+		run:         dev,
+	},
+	{
+		name:        "deploy",
+		description: "deploys a new program build to a canary room before the remaining rooms",
+		run:         deploy,
+	},
+	{
+		name:        "bootstrap",
+		description: "creates a token on the appliance and prints a profile for a remote machine",
+		run:         bootstrap,
+	},
+	{
+		name:        "audit",
+		description: "queries the audit log of mutating actions",
+		run:         queryAudit,
+		offline:     true,
+	},
+	{
+		name:        "sign",
+		description: "signs program files with an ed25519 key or generates a signing key pair",
+		run:         signFiles,
+		offline:     true,
+	},
+	{
+		name:        "verify",
+		description: "verifies the signatures of program files against the trusted keys",
+		run:         verifyFiles,
+		offline:     true,
+	},
+	{
+		name:        "rooms",
+		description: "manages the rooms",
+		commands: []command{
+			{
+				name:        "clone",
+				description: "creates a copy of a room with a new ID",
+				run:         cloneRoom,
+			},
+			{
+				name:        "create-many",
+				description: "creates rooms from an ID pattern or a CSV file",
+				run:         createManyRooms,
+			},
+			{
+				name:        "edit",
+				description: "edits a room in $EDITOR as YAML",
+				run:         editRoom,
+			},
+			{
+				name:        "rename",
+				description: "changes the ID of a room by recreating it",
+				run:         renameRoom,
+			},
+			{
+				name:        "restore",
+				description: "recreates a room from a backup saved by rename",
+				run:         restoreRoom,
+			},
+			{
+				name:        "rebind",
+				description: "moves rooms from one program to another and restarts them",
+				run:         rebindRooms,
+			},
+			{
+				name:        "userfile",
+				description: "manages the user files loaded by rooms",
+				commands: []command{
+					{
+						name:        "list",
+						description: "lists the user file of each room",
+						run:         listUserFiles,
+					},
+					{
+						name:        "push",
+						description: "uploads a user file to one or more rooms",
+						run:         pushUserFile,
+					},
+				},
+			},
+		},
+	},
+	{
+		name:        "programs",
+		description: "manages the program library",
+		commands: []command{
+			{
+				name:        "edit",
+				description: "edits the name and notes of a program in $EDITOR as YAML",
+				run:         editProgram,
+			},
+			{
+				name:        "package",
+				description: "packages a web project directory into an archive for a program",
+				run:         packageProgramFile,
+				offline:     true,
+			},
+			{
+				name:        "prune",
+				description: "deletes programs that are not used by any room",
+				run:         prunePrograms,
+			},
+			{
+				name:        "show",
+				description: "shows a program, the provenance of the uploaded file, and the rooms using it",
+				run:         showProgram,
+			},
+		},
+	},
+	{
+		name:        "tokens",
+		description: "manages the API tokens",
+		commands: []command{
+			{
+				name:        "rotate",
+				description: "replaces the token of a profile, or every profile, with a new token",
+				run:         rotateTokens,
+			},
+		},
+	},
+}
+
+// Returns true when the arguments remaining after the global flags start with a sub command.
+func IsCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	_, ok := findCommand(commands, args[0])
+	return ok
+}
+
+// Returns true when the sub command named by the arguments never sends requests to the appliance, `vcli sign` or `vcli audit`.
+func IsOffline(args []string) bool {
+	cmds := commands
+	for _, name := range args {
+		cmd, ok := findCommand(cmds, name)
+		if !ok {
+			return false
+		}
+		if len(cmd.commands) == 0 {
+			return cmd.offline
+		}
+		cmds = cmd.commands
+	}
+	return false
+}
+
+// Executes the sub command named by the arguments against the provided server.
+func Execute(vc vc.VirtualControl, args []string) error {
+	server = vc
+	confirmPolicy(server)
+	return execute(commands, "vcli", args)
+}
+
+func execute(cmds []command, path string, args []string) error {
+	if len(args) == 0 {
+		printUsage(cmds, path)
+		return fmt.Errorf("MISSING COMMAND FOR %s", strings.ToUpper(path))
+	}
+
+	cmd, ok := findCommand(cmds, args[0])
+	if !ok {
+		printUsage(cmds, path)
+		return fmt.Errorf("UNKNOWN COMMAND %s", args[0])
+	}
+
+	if len(cmd.commands) > 0 {
+		return execute(cmd.commands, path+" "+cmd.name, args[1:])
+	}
+	return cmd.run(args[1:])
+}
+
+func findCommand(cmds []command, name string) (command, bool) {
+	for _, c := range cmds {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+func printUsage(cmds []command, path string) {
+	fmt.Fprintf(os.Stderr, "\nusage: %s <command> [flags]\n\n", path)
+
+	w := tabwriter.NewWriter(os.Stderr, 0, 0, 3, ' ', 0)
+	for _, c := range cmds {
+		fmt.Fprintf(w, "  %s\t%s\n", c.name, c.description)
+	}
+	w.Flush()
+	fmt.Fprintln(os.Stderr)
+}
+
+// Parses the flags allowing them to follow the positional arguments, `rooms clone ROOM1 ROOM2 --name "Board Room 2"`.
+// The positional arguments are returned in order.
+func parseArgs(flags *flag.FlagSet, args []string) ([]string, error) {
+	positional := make([]string, 0)
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// Creates a writer used to print aligned tables to the terminal, flush the writer when the table is complete.
+func newTable() *tabwriter.Writer {
+	return tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+}
+
+// Prints the prompt and returns true when the operator types the expected value.
+func confirm(prompt string, expected string) bool {
+	fmt.Print(prompt)
+
+	answer, err := stdin.ReadString('\n')
+	if err != nil && len(answer) == 0 {
+		return false
+	}
+	return strings.TrimSpace(answer) == expected
+}
+
+// Prompts for the target of protected actions when the server enforces a policy, -force skips the prompt.
+func confirmPolicy(v vc.VirtualControl) {
+	if p, ok := v.(*vc.PolicyVC); ok {
+		p.Confirm = func(a vc.ProtectedAction) bool {
+			return confirm(fmt.Sprintf("\n⚠  %s is protected by %s, type %s to %s it: ", a.Target, strings.Join(a.Rules, ", "), a.Target, a.Action), a.Target)
+		}
+	}
+}
+
+// Parses an age such as 30d, 2w, or any value accepted by time.ParseDuration.
+// An empty value returns zero.
+func parseAge(value string) (time.Duration, error) {
+	if len(value) == 0 {
+		return 0, nil
+	}
+
+	units := map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour}
+	for suffix, unit := range units {
+		if n, ok := strings.CutSuffix(value, suffix); ok {
+			count, err := strconv.Atoi(n)
+			if err != nil {
+				return 0, fmt.Errorf("INVALID AGE %s", value)
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+
+	age, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("INVALID AGE %s", value)
+	}
+	return age, nil
+}
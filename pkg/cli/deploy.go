@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+// The time between canary health checks.
+const healthInterval = 5 * time.Second
+
+// Deploys a new build to the rooms using a program one canary room at a time.
+//
+// vcli deploy --strategy canary --file glacialis.lpz --name "Glacialis v2" --from Glacialis
+func deploy(args []string) error {
+	flags := flag.NewFlagSet("deploy", flag.ContinueOnError)
+	strategy := flags.String("strategy", "canary", "the deployment strategy, only canary is supported")
+	file := flags.String("file", "", "the program file uploaded as the new program entry")
+	name := flags.String("name", "", "the friendly name of the new program entry")
+	notes := flags.String("notes", "", "the notes of the new program entry")
+	from := flags.String("from", "", "the ID or name of the program the rooms are using")
+	canary := flags.String("canary", "", "the room moved first, the first running room when empty")
+	wait := flags.Duration("wait", 2*time.Minute, "the time allowed for the canary to start")
+	soak := flags.Duration("soak", time.Minute, "the time the canary must stay healthy before the remaining rooms are moved")
+	auto := flags.Bool("auto", false, "moves the remaining rooms once the soak time passes without asking for confirmation")
+	retire := flags.String("retire", string(vc.RetireArchive), "what happens to the previous program, archive, delete, or keep")
+	yes := flags.Bool("yes", false, "starts the deployment without asking for confirmation")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *strategy != "canary" {
+		return fmt.Errorf("UNSUPPORTED DEPLOY STRATEGY %s", *strategy)
+	}
+	if len(*file) == 0 || len(*name) == 0 || len(*from) == 0 {
+		flags.Usage()
+		return fmt.Errorf("--file, --name, AND --from ARE REQUIRED")
+	}
+	action := vc.RetireAction(*retire)
+	if action != vc.RetireArchive && action != vc.RetireDelete && action != vc.RetireKeep {
+		return fmt.Errorf("UNKNOWN RETIRE ACTION %s", *retire)
+	}
+
+	programs, err := server.GetPrograms()
+	if err != nil {
+		return err
+	}
+	source, ok := programs.Find(*from)
+	if !ok {
+		return fmt.Errorf("PROGRAM %s NOT FOUND", *from)
+	}
+
+	d, err := vc.NewCanaryDeploy(server, source, *canary)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\ndeploying %s as %s\n\n", *file, *name)
+	w := newTable()
+	fmt.Fprintf(w, "  CANARY\t%s %s\n", d.Canary.ID, d.Canary.Name)
+	fmt.Fprintf(w, "  DEVICES ONLINE\t%s\n", d.Baseline.Devices())
+	fmt.Fprintf(w, "  REMAINING ROOMS\t%d\n", len(d.Remaining))
+	fmt.Fprintf(w, "  RETIRE %s\t%s\n", source.FriendlyName, action)
+	w.Flush()
+
+	if !*yes && !confirm("\nstart the deployment? type yes to continue: ", "yes") {
+		return fmt.Errorf("DEPLOY CANCELLED")
+	}
+	fmt.Println()
+
+	if err := d.Upload(server, vc.ProgramOptions{AppFile: *file, Name: *name, Notes: *notes}); err != nil {
+		if errors.Is(err, vc.ErrDryRun) {
+			fmt.Printf("\n🧪 dry run, the deploy stops once the upload is captured as the new program doesn't exist\n\n")
+			return nil
+		}
+		return err
+	}
+	fmt.Printf("✅ uploaded %s as program %d\n", d.To.FriendlyName, d.To.ProgramID)
+
+	result := d.MoveCanary(server)
+	if result.Err != nil {
+		return rollbackCanary(d, result.Err)
+	}
+	fmt.Printf("✅ moved canary %s to %s\n", d.Canary.ID, d.To.FriendlyName)
+
+	if _, err := d.WaitForCanary(server, *wait); err != nil {
+		return rollbackCanary(d, err)
+	}
+	fmt.Printf("✅ canary %s is running\n", d.Canary.ID)
+
+	if err := soakCanary(d, *soak); err != nil {
+		return rollbackCanary(d, err)
+	}
+
+	if !*auto && !confirm(fmt.Sprintf("\nmove the remaining %d rooms to %s? type yes to continue: ", len(d.Remaining), d.To.FriendlyName), "yes") {
+		return rollbackCanary(d, fmt.Errorf("DEPLOY CANCELLED"))
+	}
+	fmt.Println()
+
+	failed := 0
+	d.Promote(server, func(result vc.RebindResult) {
+		printRebindResult(result)
+		if result.Err != nil {
+			failed++
+		}
+	})
+	if failed > 0 {
+		return fmt.Errorf("FAILED MOVING %d ROOMS, %s WAS NOT RETIRED", failed, source.FriendlyName)
+	}
+
+	if err := d.Retire(server, action); err != nil {
+		return err
+	}
+	fmt.Printf("\n✅ deployed %s to %d rooms, %s %s\n\n", d.To.FriendlyName, len(d.Remaining)+1, source.FriendlyName, retiredMessage(action))
+	return nil
+}
+
+// Checks the health of the canary until the soak time passes, the first failed check is returned.
+func soakCanary(d *vc.CanaryDeploy, soak time.Duration) error {
+	deadline := time.Now().Add(soak)
+	for {
+		health, err := d.CheckHealth(server)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("   canary %s healthy, %s devices online\n", d.Canary.ID, health.Devices())
+
+		if !time.Now().Add(healthInterval).Before(deadline) {
+			return nil
+		}
+		time.Sleep(healthInterval)
+	}
+}
+
+// Moves the canary back to the previous program and returns the reason for the rollback.
+func rollbackCanary(d *vc.CanaryDeploy, reason error) error {
+	fmt.Printf("❌ %v\n", reason)
+
+	result := d.Rollback(server)
+	if result.Err != nil {
+		return fmt.Errorf("FAILED ROLLING BACK CANARY %s: %w", d.Canary.ID, result.Err)
+	}
+	fmt.Printf("↩  moved canary %s back to %s, %s was kept for troubleshooting\n\n", d.Canary.ID, d.From.FriendlyName, d.To.FriendlyName)
+	return fmt.Errorf("DEPLOY ROLLED BACK: %w", reason)
+}
+
+func retiredMessage(action vc.RetireAction) string {
+	switch action {
+	case vc.RetireDelete:
+		return "was deleted"
+	case vc.RetireArchive:
+		return "was archived"
+	}
+	return "was kept"
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ewilliams0305/VC4-CLI/pkg/tui"
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+// Watches the program file and redeploys it to the dev room each time it is rebuilt.
+// Debugging is enabled on the room when the session starts.
+//
+// vcli dev --file ./bin/glacialis.cpz --program Glacialis --room DEV1
+func dev(args []string) error {
+	flags := flag.NewFlagSet("dev", flag.ContinueOnError)
+	file := flags.String("file", "", "the compiled program file to watch")
+	program := flags.String("program", "", "the ID or name of the program the file is uploaded to")
+	room := flags.String("room", "", "the dev room using the program")
+	timeout := flags.Duration("timeout", 2*time.Minute, "the time allowed for the room to return to running after each upload")
+	interval := flags.Duration("interval", 500*time.Millisecond, "the time between checks of the program file")
+	settle := flags.Duration("settle", 2*time.Second, "the time the file must stay unchanged before it is uploaded")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if len(*file) == 0 || len(*program) == 0 || len(*room) == 0 {
+		flags.Usage()
+		return fmt.Errorf("--file, --program, AND --room ARE REQUIRED")
+	}
+
+	session, err := vc.NewDevSession(server, *file, *program, *room, *timeout)
+	if err != nil {
+		return err
+	}
+
+	if rooms := countProgramRooms(session.Program); rooms > 1 {
+		fmt.Printf("\n⚠  %d rooms are using %s, every room is restarted on each upload\n", rooms, session.Program.FriendlyName)
+	}
+
+	if err := confirmDev(session.Program); err != nil {
+		return err
+	}
+
+	if err := session.EnableDebug(server); err != nil {
+		return err
+	}
+
+	watcher, err := vc.NewFileWatcher(session.File, *interval, *settle)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	return tui.RunDev(server, session, watcher)
+}
+
+// Returns the number of rooms using the program, zero when the rooms can't be loaded.
+func countProgramRooms(program vc.ProgramEntry) int {
+	rooms, err := server.GetRooms()
+	if err != nil {
+		return 0
+	}
+	return len(rooms.ForProgram(program.ProgramID))
+}
+
+// Confirms the dev session once when the program is used by protected rooms, every upload of the session is then allowed.
+// The interface owns the terminal while watching so uploads can't prompt for the program name.
+func confirmDev(program vc.ProgramEntry) error {
+	p, ok := server.(*vc.PolicyVC)
+	if !ok {
+		return nil
+	}
+	p.Confirm = nil
+
+	rooms, err := server.GetRooms()
+	if err != nil {
+		return err
+	}
+	target := vc.ProgramTarget(vc.Programs{program}, int(program.ProgramID))
+	protection := p.Check(vc.ProtectEditProgram, target, rooms.ForProgram(program.ProgramID)...)
+	if protection == nil {
+		return nil
+	}
+	if protection.Quiet() || !confirm(fmt.Sprintf("\n⚠  %s is protected by %s, type %s to redeploy it on every rebuild: ", target, strings.Join(protection.Rules, ", "), target), target) {
+		return protection
+	}
+	p.Policy.Force = true
+	return nil
+}
@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+)
+
+// Runs every health check against the appliance and prints the problems found.
+// An error is returned when any check fails so the command can be used in scripts.
+func doctor(args []string) error {
+	flags := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	info, err := server.DeviceInfo()
+	if err != nil {
+		return fmt.Errorf("FAILED CONNECTING TO THE VIRTUAL CONTROL SERVICE: %w", err)
+	}
+	fmt.Printf("\n✅ connected to %s running VC4 %s\n", info.Name, info.ApplicationVersion)
+
+	rooms, err := server.GetRooms()
+	if err != nil {
+		return err
+	}
+
+	problems := checkOrphanedRooms(rooms)
+
+	if problems > 0 {
+		return fmt.Errorf("FOUND %d PROBLEMS", problems)
+	}
+	fmt.Printf("✅ no problems found\n\n")
+	return nil
+}
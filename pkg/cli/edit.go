@@ -0,0 +1,190 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ewilliams0305/VC4-CLI/pkg/editor"
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+// Edits the room in $EDITOR as YAML, the changes are displayed before they are applied.
+//
+// vcli rooms edit ROOM1
+func editRoom(args []string) error {
+	flags := flag.NewFlagSet("rooms edit", flag.ContinueOnError)
+	yes := flags.Bool("yes", false, "applies the changes without asking for confirmation")
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		flags.Usage()
+		return fmt.Errorf("THE ROOM ID IS REQUIRED")
+	}
+
+	rooms, err := server.GetRooms()
+	if err != nil {
+		return err
+	}
+	found, _ := rooms.WithIDs(positional)
+	if len(found) == 0 {
+		return fmt.Errorf("ROOM %s NOT FOUND", positional[0])
+	}
+	room := found[0]
+
+	programs, err := server.GetPrograms()
+	if err != nil {
+		return err
+	}
+
+	content, err := vc.NewRoomDocument(room).Marshal()
+	if err != nil {
+		return err
+	}
+
+	options, err := editDocument("vcli-room-*.yaml", content, func(data []byte) (vc.RoomOptions, error) {
+		document, err := vc.ParseRoomDocument(data)
+		if err != nil {
+			return vc.RoomOptions{}, err
+		}
+		return document.Options(room, programs)
+	})
+	if err != nil {
+		return err
+	}
+
+	if !confirmChanges(vc.DiffFields(vc.NewRoomOptionsFromRoom(room), options), *yes) {
+		return nil
+	}
+
+	result, err := server.EditRoom(options)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\n✅ %s %s\n\n", room.ID, result.Message)
+	return nil
+}
+
+// Edits the program name and notes in $EDITOR as YAML, the changes are displayed before they are applied.
+//
+// vcli programs edit Glacialis
+func editProgram(args []string) error {
+	flags := flag.NewFlagSet("programs edit", flag.ContinueOnError)
+	yes := flags.Bool("yes", false, "applies the changes without asking for confirmation")
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		flags.Usage()
+		return fmt.Errorf("THE PROGRAM ID OR NAME IS REQUIRED")
+	}
+
+	programs, err := server.GetPrograms()
+	if err != nil {
+		return err
+	}
+	program, ok := programs.Find(positional[0])
+	if !ok {
+		return fmt.Errorf("PROGRAM %s NOT FOUND", positional[0])
+	}
+
+	content, err := vc.NewProgramDocument(program).Marshal()
+	if err != nil {
+		return err
+	}
+
+	options, err := editDocument("vcli-program-*.yaml", content, func(data []byte) (vc.ProgramOptions, error) {
+		document, err := vc.ParseProgramDocument(data)
+		if err != nil {
+			return vc.ProgramOptions{}, err
+		}
+		return document.Options(program)
+	})
+	if err != nil {
+		return err
+	}
+
+	if !confirmChanges(vc.DiffFields(vc.NewProgramOptionsFromProgram(program), options), *yes) {
+		return nil
+	}
+
+	result, err := server.EditProgram(options)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\n✅ %s %s\n\n", program.FriendlyName, result.Result)
+	return nil
+}
+
+// Opens the content in the editor until the saved file can be parsed.
+// Parse errors are added to the top of the file and the editor is opened again,
+// saving the file without changes after an error cancels the edit.
+func editDocument[T any](pattern string, content []byte, parse func([]byte) (T, error)) (T, error) {
+	var empty T
+
+	file, err := editor.TempFile(pattern, content)
+	if err != nil {
+		return empty, err
+	}
+	defer os.Remove(file)
+
+	var previous []byte
+	for {
+		data, err := editor.Edit(file)
+		if err != nil {
+			return empty, fmt.Errorf("FAILED RUNNING EDITOR: %w", err)
+		}
+
+		value, err := parse(data)
+		if err == nil {
+			return value, nil
+		}
+		if errors.Is(err, vc.ErrEditCancelled) {
+			return empty, err
+		}
+
+		data = editor.StripAnnotations(data)
+		if previous != nil && bytes.Equal(data, previous) {
+			return empty, fmt.Errorf("EDIT CANCELLED, THE FILE WAS NOT CHANGED: %w", err)
+		}
+		previous = data
+
+		if err := os.WriteFile(file, editor.Annotate(data, err), 0600); err != nil {
+			return empty, err
+		}
+	}
+}
+
+// Prints the changes and returns true when they should be applied.
+func confirmChanges(changes []vc.FieldChange, yes bool) bool {
+	if len(changes) == 0 {
+		fmt.Printf("\n✅ no changes\n\n")
+		return false
+	}
+
+	fmt.Printf("\n%d changes\n\n", len(changes))
+	printChanges(changes)
+
+	if yes {
+		return true
+	}
+	if !confirm("\napply the changes? type yes to continue: ", "yes") {
+		fmt.Printf("\nno changes were applied\n\n")
+		return false
+	}
+	return true
+}
+
+func printChanges(changes []vc.FieldChange) {
+	w := newTable()
+	fmt.Fprintln(w, "  FIELD\tBEFORE\tAFTER")
+	for _, c := range changes {
+		fmt.Fprintf(w, "  %s\t%q\t%q\n", c.Field, c.Old, c.New)
+	}
+	w.Flush()
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+// Deletes the programs that are not used by any room.
+//
+// vcli programs prune --older-than 30d --dry-run
+func prunePrograms(args []string) error {
+	flags := flag.NewFlagSet("programs prune", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "lists the unused programs without deleting them")
+	olderThan := flags.String("older-than", "", "only prune programs uploaded before this age, 30d, 12h")
+	yes := flags.Bool("yes", false, "deletes the programs without asking for confirmation")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	age, err := parseAge(*olderThan)
+	if err != nil {
+		return err
+	}
+
+	programs, err := server.GetPrograms()
+	if err != nil {
+		return err
+	}
+	rooms, err := server.GetRooms()
+	if err != nil {
+		return err
+	}
+
+	unused := vc.NewProgramUsage(rooms).Unused(programs)
+	if age > 0 {
+		unused = uploadedBefore(unused, time.Now().Add(-age))
+	}
+
+	if len(unused) == 0 {
+		fmt.Printf("\n✅ every program is used by a room\n\n")
+		return nil
+	}
+
+	fmt.Printf("\n%d programs are not used by any room\n\n", len(unused))
+	printPrograms(unused)
+
+	if *dryRun {
+		fmt.Printf("\ndry run, no programs were deleted\n\n")
+		return nil
+	}
+
+	if !*yes && !confirm(fmt.Sprintf("\ndelete %d programs? type yes to continue: ", len(unused)), "yes") {
+		return fmt.Errorf("PRUNE CANCELLED")
+	}
+
+	failed := 0
+	for _, p := range unused {
+		result, err := server.DeleteProgram(int(p.ProgramID))
+		if err != nil {
+			failed++
+			fmt.Printf("❌ %s %v\n", p.FriendlyName, err)
+			continue
+		}
+		fmt.Printf("✅ %s %s\n", p.FriendlyName, result.Result)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("FAILED DELETING %d PROGRAMS", failed)
+	}
+	return nil
+}
+
+// Filters the programs uploaded before the cutoff.
+// Programs with a time stamp that can't be read are never returned, they can't be proven old.
+func uploadedBefore(programs vc.Programs, cutoff time.Time) vc.Programs {
+	old := make(vc.Programs, 0)
+	for _, p := range programs {
+		uploaded, err := p.UploadedAt()
+		if err != nil {
+			uploaded, err = p.CompiledAt()
+		}
+		if err != nil {
+			fmt.Printf("⚠  skipping %s, unknown upload time %s\n", p.FriendlyName, p.AppFileTS)
+			continue
+		}
+		if uploaded.Before(cutoff) {
+			old = append(old, p)
+		}
+	}
+	return old
+}
+
+func printPrograms(programs vc.Programs) {
+	w := newTable()
+	fmt.Fprintln(w, "  ID\tNAME\tAPP FILE\tUPLOADED\tCOMPILED")
+	for _, p := range programs {
+		fmt.Fprintf(w, "  %d\t%s\t%s\t%s\t%s\n", p.ProgramID, p.FriendlyName, p.AppFile, p.AppFileTS, p.CompileDateTime)
+	}
+	w.Flush()
+}
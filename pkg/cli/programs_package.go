@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+// Packages a web project directory into the archive VC4 expects and checks the layout.
+// Program uploads package directories automatically, this command builds the archive locally for inspection.
+//
+// vcli programs package ./dist --type cws --exclude "*.map,.*" --output ./cws.zip
+func packageProgramFile(args []string) error {
+	flags := flag.NewFlagSet("programs package", flag.ContinueOnError)
+	kind := flags.String("type", "", "the program file the archive is built for, cws, xpanel, project, or mobility")
+	include := flags.String("include", "", "comma separated patterns, only matching files are packaged")
+	exclude := flags.String("exclude", "", "comma separated patterns, matching files and folders are skipped, hidden files are skipped when empty")
+	output := flags.String("output", "", "the archive file, defaults to the directory name with the archive extension")
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || len(*kind) == 0 {
+		flags.Usage()
+		return fmt.Errorf("THE DIRECTORY AND --type ARE REQUIRED")
+	}
+	dir := positional[0]
+
+	format, err := vc.NewArchiveFormat(*kind)
+	if err != nil {
+		return err
+	}
+
+	patterns := vc.ArchivePatterns{Include: splitList(*include)}
+	if len(*exclude) > 0 {
+		patterns.Exclude = splitList(*exclude)
+	}
+
+	archive, cleanup, err := vc.PackageDirectory(dir, format, patterns)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if len(*output) == 0 {
+		*output = filepath.Base(archive)
+	}
+	size, err := copyFile(archive, *output)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n✅ packaged %s into %s (%d bytes) for %s\n\n", dir, *output, size, format.Field)
+	return nil
+}
+
+func copyFile(source string, destination string) (int64, error) {
+	in, err := os.Open(source)
+	if err != nil {
+		return 0, err
+	}
+	defer in.Close()
+
+	out, err := os.Create(destination)
+	if err != nil {
+		return 0, err
+	}
+	defer out.Close()
+
+	size, err := io.Copy(out, in)
+	if err != nil {
+		return 0, err
+	}
+	return size, out.Close()
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+// Prints a program, the provenance stamped into its notes, and the rooms running it.
+// Provide the local program file to check it is the build that was uploaded.
+//
+// vcli programs show Glacialis --file ./bin/glacialis.cpz
+func showProgram(args []string) error {
+	flags := flag.NewFlagSet("programs show", flag.ContinueOnError)
+	file := flags.String("file", "", "compares the SHA-256 of the local program file with the uploaded file")
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("USAGE: vcli programs show NAME [--file FILE]")
+	}
+
+	programs, err := server.GetPrograms()
+	if err != nil {
+		return err
+	}
+	program, ok := programs.Find(positional[0])
+	if !ok {
+		return fmt.Errorf("PROGRAM %s NOT FOUND", positional[0])
+	}
+
+	rooms, err := server.GetRooms()
+	if err != nil {
+		return err
+	}
+
+	p, stamped := program.Provenance()
+	notes := vc.StripProvenance(program.Notes)
+	if len(notes) == 0 {
+		notes = "-"
+	}
+
+	w := newTable()
+	fmt.Fprintf(w, "\n  ID\t%d\n", program.ProgramID)
+	fmt.Fprintf(w, "  NAME\t%s\n", program.FriendlyName)
+	fmt.Fprintf(w, "  PROGRAM\t%s %s\n", program.ProgramName, program.ProgramType)
+	fmt.Fprintf(w, "  APP FILE\t%s\n", program.AppFile)
+	fmt.Fprintf(w, "  UPLOADED\t%s\n", program.AppFileTS)
+	fmt.Fprintf(w, "  COMPILED\t%s\n", program.CompileDateTime)
+	fmt.Fprintf(w, "  NOTES\t%s\n", notes)
+	w.Flush()
+
+	fmt.Printf("\nprovenance\n\n")
+	if !stamped {
+		fmt.Printf("  no provenance recorded, upload the program with -provenance to record it\n")
+	} else {
+		printProvenance(p)
+	}
+
+	using := rooms.ForProgram(program.ProgramID)
+	fmt.Printf("\n%d rooms are using %s\n\n", len(using), program.FriendlyName)
+	if len(using) > 0 {
+		w = newTable()
+		fmt.Fprintln(w, "  ROOM\tNAME\tSTATUS")
+		for _, r := range using {
+			fmt.Fprintf(w, "  %s\t%s\t%s\n", r.ID, r.Name, r.Status)
+		}
+		w.Flush()
+	}
+	fmt.Println()
+
+	if len(*file) == 0 {
+		return nil
+	}
+	if !stamped {
+		return fmt.Errorf("%s HAS NO PROVENANCE, THE LOCAL FILE CAN'T BE COMPARED", program.FriendlyName)
+	}
+	if err := p.Verify(*file); err != nil {
+		return err
+	}
+	fmt.Printf("✅ %s matches the uploaded file\n\n", *file)
+	return nil
+}
+
+func printProvenance(p vc.Provenance) {
+	commit := p.Commit
+	if len(commit) == 0 {
+		commit = "-"
+	}
+	if len(p.Tag) > 0 {
+		commit += " (" + p.Tag + ")"
+	}
+
+	w := newTable()
+	fmt.Fprintf(w, "  SHA-256\t%s\n", p.SHA256)
+	fmt.Fprintf(w, "  UPLOADED BY\t%s\n", p.Uploader)
+	fmt.Fprintf(w, "  UPLOADED AT\t%s (%s ago)\n", p.Time.Local().Format(time.DateTime), time.Since(p.Time).Round(time.Minute))
+	fmt.Fprintf(w, "  VCLI\t%s\n", p.Version)
+	fmt.Fprintf(w, "  COMMIT\t%s\n", commit)
+	w.Flush()
+}
@@ -0,0 +1,317 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+// Lists the rooms bound to programs that no longer exist and returns the number found.
+func checkOrphanedRooms(rooms vc.Rooms) int {
+	orphans := rooms.Orphaned()
+	if len(orphans) == 0 {
+		fmt.Printf("✅ every room is bound to a program\n")
+		return 0
+	}
+
+	fmt.Printf("\n⚠  %d rooms are bound to programs that no longer exist\n\n", len(orphans))
+
+	w := newTable()
+	fmt.Fprintln(w, "  ID\tNAME\tSTATUS\tMISSING PROGRAM")
+	for _, r := range orphans {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%d\n", r.ID, r.Name, r.Status, r.ProgramID)
+	}
+	w.Flush()
+
+	fmt.Printf("\n   rebind the rooms to an existing program with ctrl+b in the rooms view or delete them\n\n")
+	return len(orphans)
+}
+
+// Moves the rooms using one program to another program, running rooms are restarted one at a time.
+//
+// vcli rooms rebind --from "Glacialis v1" --to "Glacialis v2" --rooms ROOM1,ROOM2
+func rebindRooms(args []string) error {
+	flags := flag.NewFlagSet("rooms rebind", flag.ContinueOnError)
+	from := flags.String("from", "", "the ID or name of the program the rooms are using")
+	to := flags.String("to", "", "the ID or name of the program the rooms will be moved to")
+	ids := flags.String("rooms", "", "comma separated room IDs to move, all rooms using the program are moved when empty")
+	yes := flags.Bool("yes", false, "moves the rooms without asking for confirmation")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if len(*from) == 0 || len(*to) == 0 {
+		flags.Usage()
+		return fmt.Errorf("BOTH --from AND --to PROGRAMS ARE REQUIRED")
+	}
+
+	programs, err := server.GetPrograms()
+	if err != nil {
+		return err
+	}
+	source, ok := programs.Find(*from)
+	if !ok {
+		return fmt.Errorf("PROGRAM %s NOT FOUND", *from)
+	}
+	target, ok := programs.Find(*to)
+	if !ok {
+		return fmt.Errorf("PROGRAM %s NOT FOUND", *to)
+	}
+	if source.ProgramID == target.ProgramID {
+		return fmt.Errorf("ROOMS ARE ALREADY USING %s", target.FriendlyName)
+	}
+
+	rooms, err := server.GetRooms()
+	if err != nil {
+		return err
+	}
+
+	bound := rooms.ForProgram(source.ProgramID)
+	if len(*ids) > 0 {
+		selected, missing := bound.WithIDs(splitList(*ids))
+		if len(missing) > 0 {
+			return fmt.Errorf("ROOMS %s ARE NOT USING %s", strings.Join(missing, ", "), source.FriendlyName)
+		}
+		bound = selected
+	}
+
+	if len(bound) == 0 {
+		fmt.Printf("\n✅ no rooms are using %s\n\n", source.FriendlyName)
+		return nil
+	}
+
+	fmt.Printf("\n%d rooms will be moved from %s to %s, running rooms are restarted\n\n", len(bound), source.FriendlyName, target.FriendlyName)
+
+	w := newTable()
+	fmt.Fprintln(w, "  ID\tNAME\tSTATUS")
+	for _, r := range bound {
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", r.ID, r.Name, r.Status)
+	}
+	w.Flush()
+
+	if !*yes && !confirm(fmt.Sprintf("\nmove %d rooms? type yes to continue: ", len(bound)), "yes") {
+		return fmt.Errorf("REBIND CANCELLED")
+	}
+	fmt.Println()
+
+	failed := 0
+	vc.RebindRooms(server, bound, target.ProgramID, func(result vc.RebindResult) {
+		printRebindResult(result)
+		if result.Err != nil && !errors.Is(result.Err, vc.ErrDryRun) {
+			failed++
+		}
+	})
+
+	if failed > 0 {
+		return fmt.Errorf("FAILED MOVING %d ROOMS", failed)
+	}
+	return nil
+}
+
+func printRebindResult(result vc.RebindResult) {
+	switch {
+	case errors.Is(result.Err, vc.ErrDryRun):
+		fmt.Printf("🧪 %s not moved, dry run\n", result.Room.ID)
+	case result.Err != nil:
+		fmt.Printf("❌ %s %v\n", result.Room.ID, result.Err)
+	case result.Restarted:
+		fmt.Printf("✅ %s moved and restarted\n", result.Room.ID)
+	default:
+		fmt.Printf("✅ %s moved, room is %s\n", result.Room.ID, strings.ToLower(result.Room.Status))
+	}
+}
+
+// Splits a comma separated list, empty values are removed.
+func splitList(value string) []string {
+	values := make([]string, 0)
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// Creates a copy of the room with a new ID, the program, location, time zone, and notes are copied.
+//
+// vcli rooms clone ROOM1 ROOM2 --name "Board Room 2" --user-file ./config.json
+func cloneRoom(args []string) error {
+	flags := flag.NewFlagSet("rooms clone", flag.ContinueOnError)
+	name := flags.String("name", "", "the name of the new room, defaults to the name of the source room")
+	userFile := flags.String("user-file", "", "a local copy of the user file uploaded to the new room")
+	start := flags.Bool("start", false, "starts the new room once it is created")
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 2 {
+		flags.Usage()
+		return fmt.Errorf("THE SOURCE ROOM AND NEW ROOM ID ARE REQUIRED")
+	}
+	sourceId, id := positional[0], positional[1]
+
+	if err := vc.ValidateRoomId(id); err != nil {
+		return err
+	}
+	file, err := userFilePath(*userFile)
+	if err != nil {
+		return err
+	}
+
+	rooms, err := server.GetRooms()
+	if err != nil {
+		return err
+	}
+	if existing, _ := rooms.WithIDs([]string{id}); len(existing) > 0 {
+		return fmt.Errorf("ROOM %s ALREADY EXISTS", id)
+	}
+	found, _ := rooms.WithIDs([]string{sourceId})
+	if len(found) == 0 {
+		return fmt.Errorf("ROOM %s NOT FOUND", sourceId)
+	}
+	source := found[0]
+	if source.Orphaned {
+		return fmt.Errorf("ROOM %s IS BOUND TO PROGRAM %d WHICH NO LONGER EXISTS", source.ID, source.ProgramID)
+	}
+
+	if len(*name) == 0 {
+		*name = source.Name
+	}
+
+	options := vc.NewRoomOptionsFromClone(source, id, *name)
+	options.UserFile = file
+
+	result, err := server.CreateRoom(options)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\n✅ cloned %s to %s %s, %s\n", source.ID, id, *name, result.Message)
+
+	if len(source.UserFile) > 0 && len(*userFile) == 0 {
+		fmt.Printf("⚠  %s uses the user file %s which can't be copied, upload it with --user-file\n", source.ID, source.UserFile)
+	}
+
+	if *start {
+		if _, err := server.StartRoom(id); err != nil {
+			return err
+		}
+		fmt.Printf("✅ started %s\n", id)
+	}
+	fmt.Println()
+	return nil
+}
+
+// Changes the ID of a room, the room is deleted and created again with the new ID.
+//
+// vcli rooms rename ROOM1 BOARDROOM
+func renameRoom(args []string) error {
+	flags := flag.NewFlagSet("rooms rename", flag.ContinueOnError)
+	userFile := flags.String("user-file", "", "a local copy of the user file uploaded to the renamed room")
+	yes := flags.Bool("yes", false, "renames the room without asking for confirmation")
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 2 {
+		flags.Usage()
+		return fmt.Errorf("THE ROOM AND NEW ROOM ID ARE REQUIRED")
+	}
+	id, newId := positional[0], positional[1]
+
+	if err := vc.ValidateRoomId(newId); err != nil {
+		return err
+	}
+	file, err := userFilePath(*userFile)
+	if err != nil {
+		return err
+	}
+
+	rooms, err := server.GetRooms()
+	if err != nil {
+		return err
+	}
+	if existing, _ := rooms.WithIDs([]string{newId}); len(existing) > 0 {
+		return fmt.Errorf("ROOM %s ALREADY EXISTS", newId)
+	}
+	found, _ := rooms.WithIDs([]string{id})
+	if len(found) == 0 {
+		return fmt.Errorf("ROOM %s NOT FOUND", id)
+	}
+	room := found[0]
+
+	fmt.Printf("\nroom %s will be stopped, deleted, and created again as %s\n\n", room.ID, newId)
+	fmt.Printf("⚠  IP table entries are not moved, devices connected to %s must be added to %s\n", room.ID, newId)
+	if len(room.UserFile) > 0 && len(*userFile) == 0 {
+		fmt.Printf("⚠  the user file %s can't be copied, upload it with --user-file\n", room.UserFile)
+	}
+
+	if !*yes && !confirm(fmt.Sprintf("\ntype %s to rename the room: ", newId), newId) {
+		return fmt.Errorf("RENAME CANCELLED")
+	}
+
+	options := vc.NewRoomOptionsFromClone(room, newId, room.Name)
+	options.UserFile = file
+
+	result, err := vc.RenameRoom(server, room, options, vc.DefaultBackupDir())
+	if len(result.Backup) > 0 {
+		fmt.Printf("\n   saved the configuration of %s to %s\n", room.ID, result.Backup)
+	}
+	if errors.Is(err, vc.ErrDryRun) {
+		fmt.Printf("\n🧪 dry run, %s was not renamed\n\n", room.ID)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ renamed %s to %s\n", room.ID, newId)
+	if result.Debugging {
+		fmt.Printf("✅ enabled debugging on %s\n", newId)
+	}
+	if result.Started {
+		fmt.Printf("✅ started %s\n", newId)
+	}
+	fmt.Println()
+	return nil
+}
+
+// Recreates a room from a backup saved when the room was renamed.
+//
+// vcli rooms restore ~/.config/vcli/backups/ROOM1-20240101-120000.json
+func restoreRoom(args []string) error {
+	flags := flag.NewFlagSet("rooms restore", flag.ContinueOnError)
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 1 {
+		flags.Usage()
+		return fmt.Errorf("THE BACKUP FILE IS REQUIRED, BACKUPS ARE SAVED TO %s", vc.DefaultBackupDir())
+	}
+
+	backup, err := vc.LoadRoomBackup(positional[0])
+	if err != nil {
+		return err
+	}
+
+	rooms, err := server.GetRooms()
+	if err != nil {
+		return err
+	}
+	if existing, _ := rooms.WithIDs([]string{backup.Room.ID}); len(existing) > 0 {
+		return fmt.Errorf("ROOM %s ALREADY EXISTS", backup.Room.ID)
+	}
+
+	if err := vc.RestoreRoom(server, backup); err != nil {
+		return err
+	}
+	fmt.Printf("\n✅ restored %s %s saved %s\n\n", backup.Room.ID, backup.Room.Name, backup.Saved.Format(time.DateTime))
+	return nil
+}
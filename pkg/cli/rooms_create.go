@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+// Creates many rooms from an ID pattern or a CSV file with one row per room.
+//
+// vcli rooms create-many --program Conference --id 'CONF{01..20}' --name 'Conference {n}'
+//
+// vcli rooms create-many --program Conference --csv rooms.csv
+func createManyRooms(args []string) error {
+	flags := flag.NewFlagSet("rooms create-many", flag.ContinueOnError)
+	program := flags.String("program", "", "the ID or name of the program the rooms run, the CSV program column overrides it")
+	id := flags.String("id", "", "the room ID pattern, CONF{01..20} or ROOM{A,B,C}")
+	name := flags.String("name", "{id}", "the room name template, {id} is the room ID and {n} the values substituted into the ID pattern")
+	csvFile := flags.String("csv", "", "a CSV file with a header row and one room per row, columns are id,name,program,location,timezone,latitude,longitude,notes,userfile")
+	location := flags.String("location", "", "the location of every room")
+	timezone := flags.String("timezone", "", "the time zone of every room")
+	latitude := flags.String("latitude", "", "the latitude of every room")
+	longitude := flags.String("longitude", "", "the longitude of every room")
+	notes := flags.String("notes", "", "the notes of every room")
+	userFile := flags.String("user-file", "", "a local user file uploaded to every room")
+	parallel := flags.Int("parallel", 4, "the number of rooms created at the same time")
+	plan := flags.Bool("plan", false, "lists and checks the rooms without creating them, use -dry-run to display the requests")
+	yes := flags.Bool("yes", false, "creates the rooms without asking for confirmation")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if (len(*id) == 0) == (len(*csvFile) == 0) {
+		flags.Usage()
+		return fmt.Errorf("EITHER --id OR --csv IS REQUIRED")
+	}
+
+	// The --user-file is relative to the working directory, user files are validated with the rooms before any room is created.
+	defaultUserFile := *userFile
+	if len(defaultUserFile) > 0 {
+		path, err := filepath.Abs(defaultUserFile)
+		if err != nil {
+			return err
+		}
+		defaultUserFile = path
+	}
+
+	programs, err := server.GetPrograms()
+	if err != nil {
+		return err
+	}
+
+	defaults := vc.RoomOptions{
+		Location:            *location,
+		TimeZone:            *timezone,
+		Latitude:            *latitude,
+		Longitude:           *longitude,
+		Notes:               *notes,
+		UserFile:            defaultUserFile,
+		AddressSetsLocation: true,
+	}
+	if len(*program) > 0 {
+		entry, ok := programs.Find(*program)
+		if !ok {
+			return fmt.Errorf("PROGRAM %s NOT FOUND", *program)
+		}
+		defaults.ProgramLibraryId = int(entry.ProgramID)
+	}
+
+	var options []vc.RoomOptions
+	if len(*csvFile) > 0 {
+		file, err := os.Open(*csvFile)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		options, err = vc.ParseRoomsCSV(file, programs, defaults)
+		if err != nil {
+			return err
+		}
+
+		// User files in the CSV are relative to the CSV file.
+		dir := filepath.Dir(*csvFile)
+		for i, o := range options {
+			if len(o.UserFile) > 0 && !filepath.IsAbs(o.UserFile) {
+				options[i].UserFile = filepath.Join(dir, o.UserFile)
+			}
+		}
+	} else {
+		options, err = vc.ExpandRoomTemplate(*id, *name, defaults)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(options) == 0 {
+		return fmt.Errorf("NO ROOMS TO CREATE")
+	}
+
+	rooms, err := server.GetRooms()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d rooms will be created\n\n", len(options))
+	printRoomOptions(options, programs)
+
+	if errs := vc.ValidateNewRooms(rooms, options); len(errs) > 0 {
+		fmt.Println()
+		for _, err := range errs {
+			fmt.Printf("❌ %v\n", err)
+		}
+		fmt.Println()
+		return fmt.Errorf("FOUND %d PROBLEMS, NO ROOMS WERE CREATED", len(errs))
+	}
+
+	if *plan {
+		fmt.Printf("\nplan only, no rooms were created\n\n")
+		return nil
+	}
+
+	if !*yes && !confirm(fmt.Sprintf("\ncreate %d rooms? type yes to continue: ", len(options)), "yes") {
+		return fmt.Errorf("CREATE CANCELLED")
+	}
+	fmt.Println()
+
+	failed := 0
+	vc.CreateRooms(server, options, *parallel, func(result vc.CreateRoomResult) {
+		if result.Err != nil {
+			failed++
+			fmt.Printf("❌ %s %v\n", result.Options.ProgramInstanceId, result.Err)
+			return
+		}
+		fmt.Printf("✅ %s %s\n", result.Options.ProgramInstanceId, result.Options.Name)
+	})
+	fmt.Println()
+
+	if failed > 0 {
+		return fmt.Errorf("FAILED CREATING %d ROOMS", failed)
+	}
+	return nil
+}
+
+func printRoomOptions(options []vc.RoomOptions, programs vc.Programs) {
+	w := newTable()
+	fmt.Fprintln(w, "  ID\tNAME\tPROGRAM\tLOCATION\tTIME ZONE")
+	for _, o := range options {
+		program := fmt.Sprint(o.ProgramLibraryId)
+		if entry, ok := programs.Find(program); ok {
+			program = entry.FriendlyName
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", o.ProgramInstanceId, o.Name, program, o.Location, o.TimeZone)
+	}
+	w.Flush()
+}
@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+// Lists the user file loaded by each room.
+//
+// vcli rooms userfile list
+func listUserFiles(args []string) error {
+	flags := flag.NewFlagSet("rooms userfile list", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	rooms, err := server.GetRooms()
+	if err != nil {
+		return err
+	}
+
+	w := newTable()
+	fmt.Fprintln(w, "\n  ID\tNAME\tSTATUS\tUSER FILE")
+	for _, r := range rooms {
+		file := r.UserFile
+		if len(file) == 0 {
+			file = "-"
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", r.ID, r.Name, r.Status, file)
+	}
+	w.Flush()
+	fmt.Println()
+	return nil
+}
+
+// Uploads a user file to one or more rooms, JSON and CSV files are validated before they are uploaded.
+//
+// vcli rooms userfile push ROOM1 config.json --restart
+//
+// vcli rooms userfile push ROOM1,ROOM2 config.json
+//
+// vcli rooms userfile push --program Glacialis config.json
+func pushUserFile(args []string) error {
+	flags := flag.NewFlagSet("rooms userfile push", flag.ContinueOnError)
+	program := flags.String("program", "", "pushes the file to every room using the program, the ID or name of the program")
+	restart := flags.Bool("restart", false, "restarts running rooms so the user file is loaded")
+	yes := flags.Bool("yes", false, "pushes the file to many rooms without asking for confirmation")
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	switch {
+	case len(*program) > 0 && len(positional) == 1:
+	case len(*program) == 0 && len(positional) == 2:
+		ids = splitList(positional[0])
+	default:
+		flags.Usage()
+		return fmt.Errorf("THE ROOM IDS AND USER FILE ARE REQUIRED, OR --program AND THE USER FILE")
+	}
+	file := positional[len(positional)-1]
+
+	if err := vc.ValidateUserFile(file); err != nil {
+		return err
+	}
+
+	rooms, err := server.GetRooms()
+	if err != nil {
+		return err
+	}
+
+	var selected vc.Rooms
+	if len(*program) > 0 {
+		programs, err := server.GetPrograms()
+		if err != nil {
+			return err
+		}
+		entry, ok := programs.Find(*program)
+		if !ok {
+			return fmt.Errorf("PROGRAM %s NOT FOUND", *program)
+		}
+		selected = rooms.ForProgram(entry.ProgramID)
+		if len(selected) == 0 {
+			return fmt.Errorf("NO ROOMS ARE USING %s", entry.FriendlyName)
+		}
+	} else {
+		found, missing := rooms.WithIDs(ids)
+		if len(missing) > 0 {
+			return fmt.Errorf("ROOMS %s NOT FOUND", strings.Join(missing, ", "))
+		}
+		selected = found
+	}
+
+	if len(selected) > 1 {
+		fmt.Printf("\n%s will be uploaded to %d rooms\n\n", file, len(selected))
+
+		w := newTable()
+		fmt.Fprintln(w, "  ID\tNAME\tSTATUS\tUSER FILE")
+		for _, r := range selected {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", r.ID, r.Name, r.Status, r.UserFile)
+		}
+		w.Flush()
+
+		if !*yes && !confirm(fmt.Sprintf("\npush the user file to %d rooms? type yes to continue: ", len(selected)), "yes") {
+			return fmt.Errorf("PUSH CANCELLED")
+		}
+	}
+	fmt.Println()
+
+	failed := 0
+	vc.PushUserFiles(server, selected, file, *restart, func(result vc.UserFileResult) {
+		printUserFileResult(result, *restart)
+		if result.Err != nil {
+			failed++
+		}
+	})
+	fmt.Println()
+
+	if failed > 0 {
+		return fmt.Errorf("FAILED PUSHING THE USER FILE TO %d ROOMS", failed)
+	}
+	return nil
+}
+
+func printUserFileResult(result vc.UserFileResult, restart bool) {
+	switch {
+	case result.Err != nil:
+		fmt.Printf("❌ %s %v\n", result.Room.ID, result.Err)
+	case result.Restarted:
+		fmt.Printf("✅ %s user file uploaded and room restarted\n", result.Room.ID)
+	case restart:
+		fmt.Printf("✅ %s user file uploaded, room is %s\n", result.Room.ID, strings.ToLower(result.Room.Status))
+	default:
+		fmt.Printf("✅ %s user file uploaded, restart the room to load the file\n", result.Room.ID)
+	}
+}
+
+// Validates the optional --user-file of a room command and returns the full path of the file.
+func userFilePath(file string) (string, error) {
+	if len(file) == 0 {
+		return "", nil
+	}
+	if err := vc.ValidateUserFile(file); err != nil {
+		return "", err
+	}
+	return filepath.Abs(file)
+}
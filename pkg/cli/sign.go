@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"flag"
+	"fmt"
+
+	"github.com/ewilliams0305/VC4-CLI/pkg/config"
+	"github.com/ewilliams0305/VC4-CLI/pkg/tui"
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+// Writes a detached ed25519 signature next to each file, or generates a new signing key pair.
+//
+// vcli sign --generate ~/.vcli/release
+// vcli sign --key ~/.vcli/release ./bin/glacialis.cpz ./dist.ch5z
+func signFiles(args []string) error {
+	flags := flag.NewFlagSet("sign", flag.ContinueOnError)
+	key := flags.String("key", "", "the private key used to sign the files")
+	generate := flags.String("generate", "", "generates a key pair, the private key is written to the path and the public key to the path with .pub appended")
+	files, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if len(*generate) > 0 {
+		public, err := vc.GenerateKey(config.ExpandPath(*generate))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("\n✅ generated key %s\n\n", vc.KeyID(public))
+		fmt.Printf("  private key   %s, keep this file on the build machine\n", *generate)
+		fmt.Printf("  public key    %s.pub\n", *generate)
+		fmt.Printf("  base64        %s\n\n", base64.StdEncoding.EncodeToString(public))
+		fmt.Printf("add the public key to the trusted_keys of each profile that should accept files signed by this key\n\n")
+		return nil
+	}
+
+	if len(*key) == 0 || len(files) == 0 {
+		return fmt.Errorf("USAGE: vcli sign --key KEY FILE [FILE...] OR vcli sign --generate PATH")
+	}
+
+	private, err := vc.LoadPrivateKey(config.ExpandPath(*key))
+	if err != nil {
+		return err
+	}
+	id := vc.KeyID(private.Public().(ed25519.PublicKey))
+
+	for _, file := range files {
+		signature, err := vc.SignFile(file, private)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ %s signed by %s\n", signature, id)
+	}
+	return nil
+}
+
+// Verifies the detached signatures of the files against the provided keys or the trusted keys of the profile.
+//
+// vcli -profile production verify ./bin/glacialis.cpz
+// vcli verify --key ~/.vcli/release.pub ./bin/glacialis.cpz
+func verifyFiles(args []string) error {
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	keys := flags.String("key", "", "comma separated public keys, PEM files or base64, used instead of the trusted keys of the profile")
+	files, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("USAGE: vcli verify [--key KEY[,KEY]] FILE [FILE...]")
+	}
+
+	trusted := make([]ed25519.PublicKey, 0)
+	if len(*keys) > 0 {
+		for _, k := range splitList(*keys) {
+			key, err := vc.LoadPublicKey(config.ExpandPath(k))
+			if err != nil {
+				return err
+			}
+			trusted = append(trusted, key)
+		}
+	} else {
+		policy, err := tui.SignaturePolicy()
+		if err != nil {
+			return err
+		}
+		trusted = policy.Keys
+	}
+	if len(trusted) == 0 {
+		return fmt.Errorf("NO TRUSTED KEYS, PROVIDE --key OR A -profile WITH trusted_keys")
+	}
+
+	failed := 0
+	for _, file := range files {
+		key, err := vc.VerifyFile(file, trusted)
+		if err != nil {
+			failed++
+			fmt.Printf("❌ %s %v\n", file, err)
+			continue
+		}
+		fmt.Printf("✅ %s signed by %s\n", file, vc.KeyID(key))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d OF %d FILES FAILED VERIFICATION", failed, len(files))
+	}
+	return nil
+}
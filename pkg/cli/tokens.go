@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/ewilliams0305/VC4-CLI/pkg/config"
+	"github.com/ewilliams0305/VC4-CLI/pkg/tui"
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+// The profiles sharing a host and token, the token is rotated once and saved to every profile.
+type tokenGroup struct {
+	profile  config.Profile
+	profiles []string
+}
+
+// Replaces the token of a profile with a new token and deletes the old token, the profile is updated in the configuration file.
+// With --all the token of every profile is rotated, a failed profile is rolled back without stopping the remaining profiles.
+//
+// vcli tokens rotate --profile site-a
+// vcli tokens rotate --all
+func rotateTokens(args []string) error {
+	flags := flag.NewFlagSet("tokens rotate", flag.ContinueOnError)
+	profile := flags.String("profile", tui.ProfileName, "the profile whose token is rotated, defaults to the active profile")
+	all := flags.Bool("all", false, "rotates the token of every profile")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	c, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	names := []string{*profile}
+	if *all {
+		names = c.Names()
+	} else if len(*profile) == 0 {
+		return fmt.Errorf("--profile OR --all IS REQUIRED")
+	} else if _, err := c.Profile(*profile); err != nil {
+		return err
+	}
+
+	groups := make([]*tokenGroup, 0)
+	for _, name := range names {
+		p := c.Profiles[name]
+		if len(p.Host) == 0 || len(p.Token) == 0 {
+			fmt.Printf("\n⏭  %s has no host and token, local connections don't use a token\n", name)
+			continue
+		}
+		if !containsProfile(groups, name) {
+			groups = append(groups, newTokenGroup(c, p))
+		}
+	}
+	if len(groups) == 0 {
+		return fmt.Errorf("NO TOKENS TO ROTATE")
+	}
+
+	failed := 0
+	for _, g := range groups {
+		result, err := rotateGroup(g)
+		profiles := strings.Join(g.profiles, ", ")
+		if err != nil {
+			failed++
+			fmt.Printf("\n❌ %s %s\n   %v\n", profiles, g.profile.Host, err)
+			continue
+		}
+		fmt.Printf("\n✅ %s %s rotated %s to %s, %s\n", profiles, g.profile.Host, vc.RedactSecret(result.Old.Token), vc.RedactSecret(result.New.Token), result.Old.Description)
+	}
+	fmt.Println()
+
+	if failed > 0 {
+		return fmt.Errorf("FAILED ROTATING %d OF %d TOKENS, THE FAILED PROFILES STILL USE THEIR CURRENT TOKEN", failed, len(groups))
+	}
+	return nil
+}
+
+// Collects every profile using the host and token of the profile, the profiles would stop working if only one was updated.
+func newTokenGroup(c *config.Config, p config.Profile) *tokenGroup {
+	g := &tokenGroup{profile: p}
+	for _, name := range c.Names() {
+		other := c.Profiles[name]
+		if strings.EqualFold(other.Host, p.Host) && other.Token == p.Token {
+			g.profiles = append(g.profiles, name)
+		}
+	}
+	return g
+}
+
+func containsProfile(groups []*tokenGroup, name string) bool {
+	for _, g := range groups {
+		for _, p := range g.profiles {
+			if p == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func rotateGroup(g *tokenGroup) (vc.RotateResult, error) {
+	current, err := tui.NewProfileServer(g.profiles[0], g.profile, g.profile.Token)
+	if err != nil {
+		return vc.RotateResult{}, err
+	}
+
+	connect := func(token string) (vc.VirtualControl, error) {
+		return tui.NewProfileServer(g.profiles[0], g.profile, token)
+	}
+
+	// The configuration is loaded again for each save so profiles changed by an earlier rotation are kept.
+	save := func(token string) error {
+		c, err := config.Load()
+		if err != nil {
+			return err
+		}
+		for _, name := range g.profiles {
+			p := c.Profiles[name]
+			p.Token = token
+			c.Profiles[name] = p
+		}
+		return c.Save()
+	}
+
+	return vc.RotateToken(current, g.profile.Token, connect, save)
+}
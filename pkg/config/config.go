@@ -0,0 +1,145 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+// The environment variable used to load the configuration from a different file.
+const ConfigEnv = "VCLI_CONFIG"
+
+// The environment variable used to select a profile when the -profile flag isn't provided.
+const ProfileEnv = "VCLI_PROFILE"
+
+// The vcli configuration file, a set of named appliance profiles.
+//
+//	{
+//	  "profiles": {
+//	    "production": { "host": "10.0.0.111", "token": "...", "trusted_keys": ["~/.vcli/release.pub"], "require_signatures": true }
+//	  }
+//	}
+type Config struct {
+	Profiles map[string]Profile `json:"profiles"`
+
+	path string
+}
+
+// The appliance and policies used when the profile is selected with -profile.
+type Profile struct {
+	Host  string `json:"host,omitempty"`
+	Token string `json:"token,omitempty"`
+	// The SHA-256 fingerprint of the appliance certificate, connections presenting another certificate are refused.
+	CertFingerprint string `json:"cert_fingerprint,omitempty"`
+	// Public keys trusted to sign program files, the path of a PEM file or a base64 encoded ed25519 key.
+	TrustedKeys []string `json:"trusted_keys,omitempty"`
+	// Refuses to upload program and ancillary files without a valid signature from a trusted key.
+	RequireSignatures bool `json:"require_signatures,omitempty"`
+	// The audit log written by the profile, defaults to audit.jsonl in the vcli folder of the user config directory.
+	AuditLog string `json:"audit_log,omitempty"`
+	// Forwards audit entries to a syslog collector, udp://10.0.0.5:514 or tcp://logs:6514.
+	AuditSyslog string `json:"audit_syslog,omitempty"`
+	// Rooms matching a pattern or tag require the room ID to be typed, or -force, before they are stopped, restarted, or deleted.
+	Protect []vc.ProtectionRule `json:"protect,omitempty"`
+	// The daily window during which actions on protected rooms are blocked, even with -force.
+	QuietHours *vc.QuietHours `json:"quiet_hours,omitempty"`
+}
+
+// Returns the path of the configuration file, $VCLI_CONFIG or vcli/config.json in the user config directory.
+func Path() (string, error) {
+	if path := os.Getenv(ConfigEnv); len(path) > 0 {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "vcli", "config.json"), nil
+}
+
+// Returns the default audit log, audit.jsonl in the vcli folder of the user config directory.
+func AuditPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "vcli", "audit.jsonl"), nil
+}
+
+// Loads the configuration file, a missing file is returned as an empty configuration.
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Config{Profiles: map[string]Profile{}, path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("FAILED READING %s: %w", path, err)
+	}
+	if c.Profiles == nil {
+		c.Profiles = map[string]Profile{}
+	}
+	return c, nil
+}
+
+// Returns the named profile.
+func (c *Config) Profile(name string) (Profile, error) {
+	p, ok := c.Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("PROFILE %s NOT FOUND IN %s", name, c.path)
+	}
+	return p, nil
+}
+
+// Returns the profile names in alphabetical order.
+func (c *Config) Names() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Writes the configuration back to the file it was loaded from, the file is only readable by the user as it contains tokens.
+func (c *Config) Save() error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return err
+	}
+
+	// Written to a temporary file first so a failed write never truncates the existing profiles.
+	temp := c.path + ".tmp"
+	if err := os.WriteFile(temp, append(data, '\n'), 0600); err != nil {
+		return err
+	}
+	return os.Rename(temp, c.path)
+}
+
+// Expands a leading ~ to the user home directory.
+func ExpandPath(path string) string {
+	if len(path) < 2 || path[:2] != "~/" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
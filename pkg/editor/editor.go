@@ -0,0 +1,71 @@
+// Package editor opens files in the text editor configured by the user.
+package editor
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Returns the command opening the file in $VISUAL or $EDITOR, vi is used when neither is set or both are blank.
+// The editor value may include arguments, "code --wait".
+func Command(file string) *exec.Cmd {
+	args := strings.Fields(os.Getenv("VISUAL"))
+	if len(args) == 0 {
+		args = strings.Fields(os.Getenv("EDITOR"))
+	}
+	if len(args) == 0 {
+		args = []string{"vi"}
+	}
+
+	return exec.Command(args[0], append(args[1:], file)...)
+}
+
+// Writes the content to a new temporary file, the pattern follows os.CreateTemp, "vcli-room-*.yaml".
+// The caller removes the file once editing is complete.
+func TempFile(pattern string, content []byte) (string, error) {
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if _, err := file.Write(content); err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+	return file.Name(), nil
+}
+
+// Opens the file in the editor attached to the terminal and returns the saved content.
+func Edit(file string) ([]byte, error) {
+	cmd := Command(file)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(file)
+}
+
+const errorPrefix = "# ERROR: "
+
+// Adds the error to the top of the content as comments so it is visible when the file is opened again.
+// Errors added by a previous call are replaced.
+func Annotate(content []byte, err error) []byte {
+	message := errorPrefix + strings.ReplaceAll(err.Error(), "\n", "\n"+errorPrefix) + "\n"
+	return append([]byte(message), StripAnnotations(content)...)
+}
+
+// Removes the error comments added by Annotate.
+func StripAnnotations(content []byte) []byte {
+	lines := strings.SplitAfter(string(content), "\n")
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if !strings.HasPrefix(line, errorPrefix) {
+			kept = append(kept, line)
+		}
+	}
+	return []byte(strings.Join(kept, ""))
+}
@@ -55,7 +55,7 @@ func renderBanner(model BannerModel) string {
 		bg = lipgloss.Color(ErrorColor)
 	}
 
-	return lipgloss.NewStyle().
+	banner := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color(PrimaryLight)).
 		Background(lipgloss.Color(bg)).
@@ -65,4 +65,12 @@ func renderBanner(model BannerModel) string {
 		Width(model.width).
 		Align(lipgloss.Center).
 		Render(model.message + "\n")
+
+	if ReadOnly {
+		banner += "\n" + renderReadOnly(model.width)
+	}
+	if DryRun {
+		return banner + "\n" + renderDryRun(model.width)
+	}
+	return banner
 }
@@ -0,0 +1,218 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+// The number of deploys listed below the status line.
+const devHistory = 8
+
+// A compact view of a vcli dev session, the program is redeployed each time the watched file changes.
+type DevModel struct {
+	session *vc.DevSession
+	watcher *vc.FileWatcher
+	ctx     context.Context
+	cancel  context.CancelFunc
+
+	deploying bool
+	// A change was detected while deploying, the file is deployed again once the current deploy completes.
+	queued  bool
+	started time.Time
+	deploys int
+	history []string
+	err     error
+}
+
+type devChangedMsg struct {
+	info os.FileInfo
+	err  error
+}
+
+type devDeployedMsg struct {
+	room    vc.Room
+	elapsed time.Duration
+	err     error
+}
+
+type devStatusMsg vc.Room
+
+type devTick time.Time
+
+// Runs the compact dev session view until the operator quits.
+func RunDev(v vc.VirtualControl, session *vc.DevSession, watcher *vc.FileWatcher) error {
+	server = v
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := DevModel{
+		session: session,
+		watcher: watcher,
+		ctx:     ctx,
+		cancel:  cancel,
+		history: make([]string, 0, devHistory),
+	}
+
+	_, err := tea.NewProgram(m).Run()
+	return err
+}
+
+func (m DevModel) Init() tea.Cmd {
+	return tea.Batch(m.watch(), devStatusTick())
+}
+
+// Waits for the next change of the program file.
+func (m DevModel) watch() tea.Cmd {
+	return func() tea.Msg {
+		info, err := m.watcher.Next(m.ctx)
+		return devChangedMsg{info: info, err: err}
+	}
+}
+
+func (m DevModel) deploy() (DevModel, tea.Cmd) {
+	m.deploying = true
+	m.queued = false
+	m.started = time.Now()
+	m.err = nil
+
+	session := m.session
+	return m, func() tea.Msg {
+		start := time.Now()
+		room, err := session.Deploy(server)
+		return devDeployedMsg{room: room, elapsed: time.Since(start), err: err}
+	}
+}
+
+func devStatusTick() tea.Cmd {
+	return tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
+		return devTick(t)
+	})
+}
+
+func (m DevModel) roomStatus() tea.Msg {
+	rooms, err := server.GetRooms()
+	if err != nil {
+		return err
+	}
+	found, _ := rooms.WithIDs([]string{m.session.Room.ID})
+	if len(found) == 0 {
+		return fmt.Errorf("ROOM %s NOT FOUND", m.session.Room.ID)
+	}
+	return devStatusMsg(found[0])
+}
+
+func (m DevModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case error:
+		m.err = msg
+		return m, nil
+
+	case devTick:
+		if m.deploying {
+			return m, devStatusTick()
+		}
+		return m, tea.Batch(m.roomStatus, devStatusTick())
+
+	case devStatusMsg:
+		m.session.Room = vc.Room(msg)
+		return m, nil
+
+	case devChangedMsg:
+		if msg.err != nil {
+			if m.ctx.Err() != nil {
+				return m, nil
+			}
+			m.err = msg.err
+			return m, m.watch()
+		}
+		if m.deploying {
+			m.queued = true
+			return m, m.watch()
+		}
+		next, cmd := m.deploy()
+		return next, tea.Batch(cmd, next.watch())
+
+	case devDeployedMsg:
+		m.deploying = false
+		m.deploys++
+		m.session.Room = msg.room
+
+		entry := fmt.Sprintf("%s  ✅ %s running in %s", time.Now().Format("15:04:05"), m.session.Room.ID, msg.elapsed.Round(100*time.Millisecond))
+		if msg.err != nil {
+			m.err = msg.err
+			entry = fmt.Sprintf("%s  ❌ %v", time.Now().Format("15:04:05"), msg.err)
+		}
+		m.history = append([]string{entry}, m.history...)
+		if len(m.history) > devHistory {
+			m.history = m.history[:devHistory]
+		}
+
+		if m.queued {
+			return m.deploy()
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			m.cancel()
+			return m, tea.Quit
+		case "r":
+			if !m.deploying {
+				return m.deploy()
+			}
+		case "d":
+			if !m.deploying {
+				return m, m.toggleDebug()
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m DevModel) toggleDebug() tea.Cmd {
+	room := m.session.Room
+	return func() tea.Msg {
+		if _, err := server.DebugRoom(room.ID, !room.Debugging); err != nil {
+			return err
+		}
+		return m.roomStatus()
+	}
+}
+
+func (m DevModel) View() string {
+	room := m.session.Room
+
+	s := HighlightedText.Render("⚡ vcli dev") + fmt.Sprintf("  %s → %s\n", room.ID, m.session.Program.FriendlyName)
+	s += GreyedOutText.Render(fmt.Sprintf("   watching %s", m.session.File)) + "\n\n"
+
+	state := "watching for changes"
+	if m.deploying {
+		state = fmt.Sprintf("deploying, %s elapsed", time.Since(m.started).Round(time.Second))
+		if m.queued {
+			state += ", another change is queued"
+		}
+	}
+	s += fmt.Sprintf(" %s %-10s  DEBUG %s  DEPLOYS %d  %s\n", GetStatus(room.Status), room.Status, CheckMark(room.Debugging), m.deploys, state)
+
+	if m.err != nil {
+		s += "\n" + RenderWarningBox(0).Height(0).PaddingTop(0).PaddingBottom(0).MarginBottom(0).Render(m.err.Error()) + "\n"
+	}
+
+	if len(m.history) > 0 {
+		s += "\n"
+		for _, h := range m.history {
+			s += " " + h + "\n"
+		}
+	}
+
+	s += GreyedOutText.Render("\n r redeploy • d toggle debug • q quit") + "\n"
+	return s
+}
@@ -13,6 +13,10 @@ type DeviceTableModel struct {
 	Help          HelpModel
 	row           string
 	width, height int
+	// The device info and columns of the table, copied by the yank key.
+	info    vc.DeviceInfo
+	columns []table.Column
+	yank    yanker
 }
 
 func NewDeviceInfo(width, height int) DeviceTableModel {
@@ -43,6 +47,9 @@ func (m DeviceTableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return NewDeviceErrorTable(msg, m.width), nil
 
 	case tea.KeyMsg:
+		if m.yank.handle(msg, m.columns, m.Table.SelectedRow(), m.info) {
+			return m, nil
+		}
 		switch msg.String() {
 		case "q", "ctrl+q", "esc":
 			return ReturnToHomeModel(info), tea.Batch(tick, DeviceInfoCommand)
@@ -69,6 +76,7 @@ func (m DeviceTableModel) View() string {
 		s += RenderMessageBox(m.width).Render(m.row)
 	}
 
+	s += m.yank.View(m.columns)
 	s += m.Help.renderHelpInfo()
 
 	return s
@@ -174,9 +182,11 @@ func NewDeviceTable(info vc.DeviceInfo, width int) DeviceTableModel {
 	t.Blur()
 
 	return DeviceTableModel{
-		Table: t,
-		Help:  NewHelpModel(),
-		width: width}
+		Table:   t,
+		Help:    NewHelpModel(),
+		width:   width,
+		info:    info,
+		columns: columns}
 }
 
 func NewDeviceErrorTable(msg vc.VirtualControlError, width int) DeviceTableModel {
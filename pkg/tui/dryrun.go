@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+// The number of captured requests listed below the banner.
+const dryRunHistory = 3
+
+// Requests captured in dry run mode, the commands capturing them run outside of the bubbletea update loop.
+var dryRunRequests struct {
+	mu       sync.Mutex
+	requests []vc.DryRunRequest
+	count    int
+}
+
+// Prints the captured request when running a command, the interface lists the latest requests below the banner.
+func captureDryRun(r vc.DryRunRequest) {
+	if app == nil {
+		fmt.Printf("🧪 DRY RUN %s\n", r)
+		return
+	}
+
+	dryRunRequests.mu.Lock()
+	defer dryRunRequests.mu.Unlock()
+
+	dryRunRequests.count++
+	dryRunRequests.requests = append([]vc.DryRunRequest{r}, dryRunRequests.requests...)
+	if len(dryRunRequests.requests) > dryRunHistory {
+		dryRunRequests.requests = dryRunRequests.requests[:dryRunHistory]
+	}
+}
+
+// Renders the dry run notice and the latest captured requests, nothing is rendered unless -dry-run was provided.
+func renderDryRun(width int) string {
+	if !DryRun {
+		return ""
+	}
+
+	dryRunRequests.mu.Lock()
+	defer dryRunRequests.mu.Unlock()
+
+	lines := []string{fmt.Sprintf("DRY RUN, changes are not sent to the appliance, %d requests captured", dryRunRequests.count)}
+	for _, r := range dryRunRequests.requests {
+		line := r.Method + " " + r.URL
+		if fields := r.Fields(); len(fields) > 0 {
+			line += "\n     " + strings.Join(fields, " ")
+		}
+		lines = append(lines, "  → "+line)
+	}
+
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFFFFF")).
+		Background(lipgloss.Color(WarningColor)).
+		PaddingLeft(1).
+		Width(width).
+		Render(strings.Join(lines, "\n")) + "\n"
+}
@@ -0,0 +1,301 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/ewilliams0305/VC4-CLI/pkg/editor"
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+// Edits a room or program as YAML in $EDITOR.
+// The TUI is suspended while the editor is open, the changes are displayed and confirmed before they are applied.
+type YamlEditorModel struct {
+	title   string
+	pattern string
+	file    string
+	load    tea.Cmd
+	back    func() (tea.Model, tea.Cmd)
+	parse   documentParser
+	changes []vc.FieldChange
+	apply   tea.Cmd
+	form    *huh.Form
+	invalid bool
+	running bool
+	result  string
+	err     error
+}
+
+// Parses the saved file and returns the changes and the command applying them.
+type documentParser func(data []byte) ([]vc.FieldChange, tea.Cmd, error)
+
+// The YAML opened in the editor and the parser validating the saved file.
+type editorDocumentMessage struct {
+	content []byte
+	parse   documentParser
+}
+
+type editorClosedMessage struct{ err error }
+
+var editorApply bool
+
+// Edits the room in $EDITOR, the program library is loaded to validate the program ID.
+func RoomEditorModel(room *vc.Room) YamlEditorModel {
+	return YamlEditorModel{
+		title:   fmt.Sprintf("\n📝 Edit Room %s\n", room.ID),
+		pattern: "vcli-room-*.yaml",
+		load:    roomDocumentQuery(*room),
+		back: func() (tea.Model, tea.Cmd) {
+			return ReturnRoomsModel(), tea.Batch(tick, RoomsQuery)
+		},
+	}
+}
+
+// Edits the name and notes of the program in $EDITOR.
+func ProgramEditorModel(program *vc.ProgramEntry) YamlEditorModel {
+	return YamlEditorModel{
+		title:   fmt.Sprintf("\n📝 Edit Program %s\n", program.FriendlyName),
+		pattern: "vcli-program-*.yaml",
+		load:    programDocumentQuery(*program),
+		back: func() (tea.Model, tea.Cmd) {
+			return ReturnToPrograms(), tea.Batch(tick, ProgramsQuery)
+		},
+	}
+}
+
+func roomDocumentQuery(room vc.Room) tea.Cmd {
+	return func() tea.Msg {
+		programs, err := server.GetPrograms()
+		if err != nil {
+			return err
+		}
+		content, err := vc.NewRoomDocument(room).Marshal()
+		if err != nil {
+			return err
+		}
+
+		return editorDocumentMessage{
+			content: content,
+			parse: func(data []byte) ([]vc.FieldChange, tea.Cmd, error) {
+				document, err := vc.ParseRoomDocument(data)
+				if err != nil {
+					return nil, nil, err
+				}
+				options, err := document.Options(room, programs)
+				if err != nil {
+					return nil, nil, err
+				}
+				return vc.DiffFields(vc.NewRoomOptionsFromRoom(room), options), EditRoom(options), nil
+			},
+		}
+	}
+}
+
+func programDocumentQuery(program vc.ProgramEntry) tea.Cmd {
+	return func() tea.Msg {
+		content, err := vc.NewProgramDocument(program).Marshal()
+		if err != nil {
+			return err
+		}
+
+		return editorDocumentMessage{
+			content: content,
+			parse: func(data []byte) ([]vc.FieldChange, tea.Cmd, error) {
+				document, err := vc.ParseProgramDocument(data)
+				if err != nil {
+					return nil, nil, err
+				}
+				options, err := document.Options(program)
+				if err != nil {
+					return nil, nil, err
+				}
+				apply := func() tea.Msg { return EditProgram(options) }
+				return vc.DiffFields(vc.NewProgramOptionsFromProgram(program), options), apply, nil
+			},
+		}
+	}
+}
+
+func (m YamlEditorModel) Init() tea.Cmd {
+	return m.load
+}
+
+// Writes the content to a temporary file and suspends the TUI while the editor is open.
+func (m YamlEditorModel) openEditor(content []byte) (YamlEditorModel, tea.Cmd) {
+	if len(m.file) == 0 {
+		file, err := editor.TempFile(m.pattern, content)
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.file = file
+	} else if err := os.WriteFile(m.file, content, 0600); err != nil {
+		m.err = err
+		return m, nil
+	}
+
+	return m, tea.ExecProcess(editor.Command(m.file), func(err error) tea.Msg {
+		return editorClosedMessage{err}
+	})
+}
+
+func (m YamlEditorModel) close() (tea.Model, tea.Cmd) {
+	if len(m.file) > 0 {
+		os.Remove(m.file)
+	}
+	return m.back()
+}
+
+func (m YamlEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case error:
+		m.err = msg
+		return m, nil
+
+	case vc.RoomCreatedResult:
+		m.result = msg.Message
+		return m, nil
+
+	case vc.ProgramUploadResult:
+		m.result = msg.Result
+		return m, nil
+
+	case editorDocumentMessage:
+		m.parse = msg.parse
+		return m.openEditor(msg.content)
+
+	case editorClosedMessage:
+		return m.edited(msg)
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "ctrl+q":
+			return m.close()
+		case "e":
+			if m.invalid {
+				m.invalid = false
+				m.err = nil
+				data, err := os.ReadFile(m.file)
+				if err != nil {
+					m.err = err
+					return m, nil
+				}
+				return m.openEditor(data)
+			}
+		}
+	}
+
+	if m.form == nil {
+		return m, nil
+	}
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+
+		if m.form.State == huh.StateCompleted && !m.running {
+			if !editorApply {
+				return m.close()
+			}
+			m.running = true
+			return m, m.apply
+		}
+	}
+	return m, cmd
+}
+
+// Validates the saved file, invalid files can be opened again with the error added to the top of the file.
+func (m YamlEditorModel) edited(msg editorClosedMessage) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = fmt.Errorf("FAILED RUNNING EDITOR: %w", msg.err)
+		return m, nil
+	}
+
+	data, err := os.ReadFile(m.file)
+	if err != nil {
+		m.err = err
+		return m, nil
+	}
+
+	changes, apply, err := m.parse(data)
+	if errors.Is(err, vc.ErrEditCancelled) {
+		return m.close()
+	}
+	if err != nil {
+		m.err = err
+		m.invalid = true
+		if err := os.WriteFile(m.file, editor.Annotate(data, err), 0600); err != nil {
+			m.err = err
+			m.invalid = false
+		}
+		return m, nil
+	}
+
+	m.changes = changes
+	m.apply = apply
+	if len(changes) == 0 {
+		return m, nil
+	}
+
+	editorApply = false
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(fmt.Sprintf("Apply %d changes?", len(changes))).
+				Affirmative("Apply").
+				Negative("Discard").
+				Value(&editorApply),
+		),
+	).WithTheme(huh.ThemeDracula())
+	return m, m.form.Init()
+}
+
+func (m YamlEditorModel) View() string {
+	s := GreyedOutText.Render(m.title)
+
+	if m.file == "" && m.err == nil {
+		s += "\n" + RenderMessageBox(app.width).Render("opening the editor, please wait...")
+		return s
+	}
+
+	if m.err != nil {
+		s += RenderErrorBox("error editing document", m.err)
+		if m.invalid {
+			s += GreyedOutText.Render("\n\n e edit again • esc discard changes")
+		} else {
+			s += GreyedOutText.Render("\n\n esc return")
+		}
+		return s
+	}
+
+	if m.changes == nil {
+		return s
+	}
+
+	if len(m.changes) == 0 {
+		s += "\n" + RenderMessageBox(app.width).Render("no changes were made")
+		s += GreyedOutText.Render("\n\n esc return")
+		return s
+	}
+
+	var changes string
+	for _, c := range m.changes {
+		changes += "\n " + c.String()
+	}
+	s += "\n" + RenderMessageBox(app.width).Render(changes+"\n")
+
+	if m.result != "" {
+		s += "\n" + RenderMessageBox(app.width).Render("\n RESULT           "+m.result+"\n")
+		s += GreyedOutText.Render("\n\n esc return")
+		return s
+	}
+
+	if m.form != nil {
+		s += "\n" + m.form.View()
+	}
+	return s
+}
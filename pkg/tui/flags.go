@@ -2,6 +2,10 @@ package tui
 
 import (
 	"flag"
+	"os"
+	"time"
+
+	"github.com/ewilliams0305/VC4-CLI/pkg/config"
 )
 
 var (
@@ -20,6 +24,32 @@ var (
 	Action string
 	// When the -o flag is provided the application will override the provided room.
 	OverrideFile bool
+	// Records every interaction with the VC4 API into the provided cassette file
+	RecordFile string
+	// Replays a recorded cassette file instead of connecting to a VC4 appliance
+	ReplayFile string
+	// The maximum time spent retrying a failed request, retries are disabled when zero
+	RetryTimeout time.Duration
+	// Comma separated list of response codes that will be retried
+	RetryCodes string
+	// The time responses are cached and shared between views, caching is disabled when zero
+	CacheTTL time.Duration
+	// The profile loaded from the vcli configuration file, the profile provides the host, token, and upload policies
+	ProfileName string
+	// The append only audit log receiving every mutating request, defaults to the profile audit log or audit.jsonl in the user config directory
+	AuditFile string
+	// Forwards audit entries to a syslog collector, udp://host:514 or tcp://host:6514
+	AuditSyslog string
+	// Sends GET requests to the appliance and captures every mutating request without sending it
+	DryRun bool
+	// Writes the provenance of uploaded program files into the program notes
+	Provenance bool
+	// Disables every action changing the appliance, enabled when the token is read only
+	ReadOnly bool
+	// Allows actions on rooms protected by the profile without typing the room ID, quiet hours still block them
+	Force bool
+	// The vcli version written into the provenance of uploaded programs, set at build time with -ldflags "-X"
+	Version = "dev"
 )
 
 func InitFlags() {
@@ -32,6 +62,18 @@ func InitFlags() {
 		nameFagUsage      = "An optional glag used to name the loaded program flag"
 		roomFlagUsage     = "An optional room ID used to spin up a new room"
 		overrideFlagUsage = "An option flag to let the application know to override the provided program file"
+		recordFlagUsage   = "Records all VC4 API interactions into the provided cassette file"
+		replayFlagUsage   = "Runs the application against a recorded cassette file instead of a VC4 appliance"
+		retryFlagUsage    = "Retries failed requests with a backoff for up to the provided duration, 0 disables retries"
+		retryCodesUsage   = "Comma separated list of response codes retried when the retry flag is provided"
+		cacheFlagUsage    = "Caches API responses for the provided duration, 0 disables the cache"
+		profileFlagUsage  = "The profile loaded from the vcli configuration file, defaults to $VCLI_PROFILE"
+		auditFlagUsage    = "The audit log receiving every mutating request, defaults to audit.jsonl in the vcli config folder"
+		syslogFlagUsage   = "Forwards audit entries to a syslog collector, udp://host:514 or tcp://host:6514"
+		dryRunFlagUsage   = "Displays the requests that change the appliance instead of sending them, GET requests are still sent"
+		provenanceUsage   = "Writes the SHA-256, uploader, time, vcli version, and git commit of uploaded program files into the program notes"
+		readOnlyUsage     = "Browses the appliance without changing it, enabled automatically when the token is read only"
+		forceFlagUsage    = "Stops, restarts, deletes, and edits rooms protected by the profile without confirmation, quiet hours still apply"
 	)
 
 	flag.StringVar(&Hostname, "host", defaultHost, hostFlagUsage)
@@ -50,4 +92,25 @@ func InitFlags() {
 
 	flag.BoolVar(&OverrideFile, "override", false, overrideFlagUsage)
 	flag.BoolVar(&OverrideFile, "o", false, overrideFlagUsage+" (shorthand)")
+
+	flag.StringVar(&RecordFile, "record", "", recordFlagUsage)
+	flag.StringVar(&ReplayFile, "replay", "", replayFlagUsage)
+
+	flag.DurationVar(&RetryTimeout, "retry", 0, retryFlagUsage)
+	flag.StringVar(&RetryCodes, "retry-codes", "502,503,504", retryCodesUsage)
+
+	flag.DurationVar(&CacheTTL, "cache", 0, cacheFlagUsage)
+
+	flag.StringVar(&ProfileName, "profile", os.Getenv(config.ProfileEnv), profileFlagUsage)
+
+	flag.StringVar(&AuditFile, "audit", "", auditFlagUsage)
+	flag.StringVar(&AuditSyslog, "audit-syslog", "", syslogFlagUsage)
+
+	flag.BoolVar(&DryRun, "dry-run", false, dryRunFlagUsage)
+
+	flag.BoolVar(&Provenance, "provenance", false, provenanceUsage)
+
+	flag.BoolVar(&Force, "force", false, forceFlagUsage)
+
+	flag.BoolVar(&ReadOnly, "read-only", false, readOnlyUsage)
 }
@@ -24,6 +24,7 @@ type keyMap struct {
 	Devices  key.Binding
 	Info     key.Binding
 	Auth     key.Binding
+	Yank     key.Binding
 }
 
 // ShortHelp returns keybindings to be shown in the mini help view. It's part
@@ -37,7 +38,7 @@ func (k keyMap) ShortHelp() []key.Binding {
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Left, k.Right},          // first column
-		{k.Help, k.Quit, k.Info},                 // second column
+		{k.Help, k.Quit, k.Info, k.Yank},         // second column
 		{k.Rooms, k.Programs, k.Devices, k.Auth}, // second column
 	}
 }
@@ -91,6 +92,10 @@ var Keys = keyMap{
 		key.WithKeys("ctrl+i"),
 		key.WithHelp("ctrl+i", "info"),
 	),
+	Yank: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "copy from a table"),
+	),
 }
 
 type HelpModel struct {
@@ -22,6 +22,7 @@ type IpTableModel struct {
 	help          HelpModel
 	width, height int
 	banner        *BannerModel
+	yank          yanker
 }
 
 func InitialIpTableModel(width, height int, roomid string) *IpTableModel {
@@ -83,6 +84,9 @@ func (m IpTableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return iptable, nil
 
 	case tea.KeyMsg:
+		if iptable.yank.handle(msg, getIpTableColumns(iptable.width), iptable.table.SelectedRow(), iptable.selected) {
+			return iptable, nil
+		}
 		switch msg.String() {
 
 		case "ctrl+q", "q", "ctrl+c", "esc":
@@ -98,6 +102,7 @@ func (m IpTableModel) View() string {
 	s := m.banner.View() + "\n"
 	s += BaseStyle.Render(m.table.View()) + "\n\n"
 	s += RenderMessageBox(m.width).Render(fmt.Sprintf("IPID: %d, %s %s", m.selected.ProgramIPID, m.selected.Model, m.selected.Description))
+	s += m.yank.View(getIpTableColumns(m.width))
 	s += m.help.renderHelpInfo()
 	return s
 }
@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ewilliams0305/VC4-CLI/pkg/config"
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+// The profile selected with -profile or $VCLI_PROFILE, empty when no profile is selected.
+var ActiveProfile config.Profile
+
+// Loads the selected profile, the host and token of the profile are used unless the flags were provided.
+func loadProfile() error {
+	if len(ProfileName) == 0 {
+		return nil
+	}
+
+	c, err := config.Load()
+	if err != nil {
+		return err
+	}
+	p, err := c.Profile(ProfileName)
+	if err != nil {
+		return err
+	}
+
+	provided := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) {
+		provided[f.Name] = true
+	})
+	if len(p.Host) > 0 && !provided["host"] && !provided["h"] {
+		Hostname = p.Host
+	}
+	if len(p.Token) > 0 && !provided["token"] && !provided["t"] {
+		Token = p.Token
+	}
+
+	if len(AuditFile) == 0 {
+		AuditFile = config.ExpandPath(p.AuditLog)
+	}
+	if len(AuditSyslog) == 0 {
+		AuditSyslog = p.AuditSyslog
+	}
+
+	ActiveProfile = p
+	return nil
+}
+
+// Opens the audit log selected by the flags or profile, audit.jsonl in the vcli config folder when neither provide one.
+// Entries are recorded with the profile name.
+func openAuditLog(profile string) (*vc.AuditLog, error) {
+	if len(AuditFile) == 0 {
+		path, err := config.AuditPath()
+		if err != nil {
+			return nil, err
+		}
+		AuditFile = path
+	}
+
+	var syslog *vc.SyslogWriter
+	if len(AuditSyslog) > 0 {
+		w, err := vc.NewSyslogWriter(AuditSyslog)
+		if err != nil {
+			return nil, err
+		}
+		syslog = w
+	}
+	log, err := vc.NewAuditLog(AuditFile, profile, syslog)
+	if err != nil {
+		return nil, err
+	}
+	auditLogs = append(auditLogs, log)
+	return log, nil
+}
+
+// The audit logs opened by the clients, flushed before vcli exits.
+var auditLogs []*vc.AuditLog
+
+// The time allowed for the audit entries queued for syslog to be sent before vcli exits.
+const auditFlushTimeout = 5 * time.Second
+
+// Waits for the audit entries queued for syslog, entries that couldn't be forwarded are reported on stderr.
+func FlushAudit() {
+	for _, l := range auditLogs {
+		if err := l.Flush(auditFlushTimeout); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠  %v\n", err)
+		}
+	}
+}
+
+// Returns the signature policy of the active profile and of every profile using the target host,
+// a host requiring signatures is enforced when it is selected with -h instead of -profile.
+// The policy is empty when the profiles don't trust any keys.
+func SignaturePolicy() (vc.SignaturePolicy, error) {
+	profiles := []config.Profile{ActiveProfile}
+	if len(Hostname) > 0 {
+		c, err := config.Load()
+		if err != nil {
+			return vc.SignaturePolicy{}, err
+		}
+		for _, p := range c.Profiles {
+			if strings.EqualFold(strings.TrimSpace(p.Host), strings.TrimSpace(Hostname)) {
+				profiles = append(profiles, p)
+			}
+		}
+	}
+
+	policy := vc.SignaturePolicy{}
+	loaded := map[string]bool{}
+	for _, p := range profiles {
+		policy.Require = policy.Require || p.RequireSignatures
+		for _, k := range p.TrustedKeys {
+			path := config.ExpandPath(k)
+			if loaded[path] {
+				continue
+			}
+			loaded[path] = true
+
+			key, err := vc.LoadPublicKey(path)
+			if err != nil {
+				return policy, err
+			}
+			policy.Keys = append(policy.Keys, key)
+		}
+	}
+	return policy, nil
+}
+
+// Wraps the server with the protection policy of the active profile, the server is returned unchanged when the profile doesn't protect any rooms.
+func protectServer(server vc.VirtualControl) (vc.VirtualControl, error) {
+	if len(ActiveProfile.Protect) == 0 {
+		return server, nil
+	}
+	if err := ActiveProfile.QuietHours.Validate(); err != nil {
+		return nil, err
+	}
+	return vc.NewPolicyVC(server, vc.Policy{
+		Protect:    ActiveProfile.Protect,
+		QuietHours: ActiveProfile.QuietHours,
+		Force:      Force,
+	}), nil
+}
+
+// Creates a client connected to the host of the profile using the token, the client shares the options of the application flags.
+// Used to reach the appliance of a profile other than the active profile, or the active appliance with a new token.
+func NewProfileServer(name string, p config.Profile, token string) (vc.VirtualControl, error) {
+	opts, err := serverOptions(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.CertFingerprint) > 0 {
+		opts = append(opts, vc.WithPinnedCert(p.CertFingerprint))
+	}
+	opts = append(opts, vc.WithReadOnly(readOnlyMode))
+	if len(p.Host) > 0 && len(token) > 0 {
+		return vc.NewRemoteVC(p.Host, token, opts...), nil
+	}
+	return vc.NewLocalVC(opts...), nil
+}
@@ -20,20 +20,24 @@ type programsKeyMap struct {
 	Delete key.Binding
 	Edit   key.Binding
 	Room   key.Binding
+	Rebind key.Binding
+	Deploy key.Binding
+	Yaml   key.Binding
+	Yank   key.Binding
 }
 
 // ShortHelp returns keybindings to be shown in the mini help view. It's part
 // of the key.Map interface.
 func (k programsKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Quit, k.Up, k.Down, k.New, k.Delete, k.Edit, k.Room}
+	return []key.Binding{k.Quit, k.Up, k.Down, k.New, k.Delete, k.Edit, k.Room, k.Rebind, k.Deploy, k.Yank}
 }
 
 // FullHelp returns keybindings for the expanded help view. It's part of the
 // key.Map interface.
 func (k programsKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Help, k.Up, k.Down},            // first column
-		{k.New, k.Delete, k.Edit, k.Room}, // second column
+		{k.Help, k.Up, k.Down}, // first column
+		{k.New, k.Delete, k.Edit, k.Room, k.Rebind, k.Deploy, k.Yaml}, // second column
 	}
 }
 
@@ -70,6 +74,22 @@ var programKeys = programsKeyMap{
 		key.WithKeys("ctrl+r"),
 		key.WithHelp("ctrl+r", "create room"),
 	),
+	Rebind: key.NewBinding(
+		key.WithKeys("ctrl+b"),
+		key.WithHelp("ctrl+b", "move rooms"),
+	),
+	Deploy: key.NewBinding(
+		key.WithKeys("ctrl+u"),
+		key.WithHelp("ctrl+u", "canary deploy"),
+	),
+	Yaml: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "edit in $EDITOR"),
+	),
+	Yank: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "copy"),
+	),
 }
 
 type programsHelpModel struct {
@@ -11,34 +11,39 @@ import (
 type DeleteProgramForm struct {
 	form    *huh.Form
 	program *vc.ProgramEntry
+	impact  *vc.ProgramImpact
+	err     error
 }
 
 var (
 	progDeleteConfirm bool
 )
 
+// Deletes the program from the library.
+// The confirmation is created once the rooms using the program have been loaded.
 func DeleteProgramFormModel(program *vc.ProgramEntry) DeleteProgramForm {
+	progDeleteConfirm = false
 	return DeleteProgramForm{
 		program: program,
-		form: huh.NewForm(
-			huh.NewGroup(
-				huh.NewConfirm().
-					Title(fmt.Sprintf("Are you sure you want to delete %s and any rooms using this program?", program.ProgramName)).
-					Value(&progDeleteConfirm).
-					Affirmative("Yes").
-					Negative("Cancel"),
-			),
-		).WithTheme(huh.ThemeDracula()),
 	}
 }
 
 func (m DeleteProgramForm) Init() tea.Cmd {
-	return m.form.Init()
+	return ProgramImpactQuery(*m.program)
 }
 
 func (m DeleteProgramForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 
+	case error:
+		m.err = msg
+		return m, nil
+
+	case vc.ProgramImpact:
+		m.impact = &msg
+		m.form = impactConfirmationForm(msg, vc.ProtectDeleteProgram, fmt.Sprintf("Are you sure you want to delete %s and any rooms using this program?", m.program.ProgramName), &progDeleteConfirm)
+		return m, m.form.Init()
+
 	case bool:
 		if msg {
 			return ReturnToPrograms(), DeleteProgram(int(m.program.ProgramID))
@@ -46,11 +51,15 @@ func (m DeleteProgramForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return ReturnToPrograms(), tea.Batch(ProgramsQuery, tick)
 	case tea.KeyMsg:
 		switch msg.String() {
-		case "shift+tab":
+		case "shift+tab", "esc":
 			return ReturnToPrograms(), tea.Batch(ProgramsQuery, tick)
 		}
 	}
 
+	if m.form == nil {
+		return m, nil
+	}
+
 	form, cmd := m.form.Update(msg)
 	if f, ok := form.(*huh.Form); ok {
 		m.form = f
@@ -64,7 +73,21 @@ func (m DeleteProgramForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m DeleteProgramForm) View() string {
-	s := m.form.View()
+	s := GreyedOutText.Render(fmt.Sprintf("\n🗑 Delete Program %s\n", m.program.FriendlyName))
+
+	if m.err != nil {
+		s += RenderErrorBox("error loading rooms using the program", m.err)
+		s += GreyedOutText.Render("\n\n esc return")
+		return s
+	}
+
+	if m.impact == nil {
+		s += "\n" + RenderMessageBox(app.width).Render("loading rooms using the program, please wait...")
+		return s
+	}
+
+	s += "\n" + renderProgramImpact(*m.impact, vc.ProtectDeleteProgram, app.width)
+	s += "\n" + m.form.View()
 	return s
 }
 
@@ -72,9 +95,8 @@ func SumbitDeleteProgramForm(m *DeleteProgramForm) tea.Cmd {
 	if m.form.State != huh.StateCompleted {
 		return nil
 	}
-	return deleteProgramConfirmation
-}
-
-func deleteProgramConfirmation() tea.Msg {
-	return progDeleteConfirm
+	confirmed := impactConfirmed(*m.impact, vc.ProtectDeleteProgram, progDeleteConfirm)
+	return func() tea.Msg {
+		return confirmed
+	}
 }
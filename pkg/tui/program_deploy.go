@@ -0,0 +1,413 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+// The time between canary health checks while waiting for the operator.
+const deployHealthInterval = 5 * time.Second
+
+type deployStage int
+
+const (
+	deployForm deployStage = iota
+	deployStarting
+	deployUpload
+	deployCanary
+	deployWait
+	deployHealth
+	deployPromote
+	deployRetiring
+	deployRollback
+	deployDone
+)
+
+// The result of a single stage of the deployment.
+type deployStep struct {
+	stage deployStage
+	err   error
+}
+
+type deployHealthCheck struct {
+	health vc.RoomImpact
+	err    error
+}
+
+type CanaryDeployForm struct {
+	source  *vc.ProgramEntry
+	rooms   vc.Rooms
+	form    *huh.Form
+	promote *huh.Form
+	deploy  *vc.CanaryDeploy
+	stage   deployStage
+	health  *vc.RoomImpact
+	checked time.Time
+	pending vc.Rooms
+	failed  int
+	log     []string
+	err     error
+}
+
+var (
+	deployOptions        vc.ProgramOptions
+	deployCanaryId       string
+	deployRetire         vc.RetireAction
+	deployConfirm        bool
+	deployPromoteConfirm bool
+)
+
+// Deploys a new build of the program to a canary room, the operator promotes the build to the remaining rooms.
+// The form is created once the rooms using the program have been loaded.
+func CanaryDeployFormModel(source *vc.ProgramEntry) CanaryDeployForm {
+	deployOptions = vc.ProgramOptions{Notes: source.Notes}
+	deployCanaryId = ""
+	deployRetire = vc.RetireArchive
+	deployConfirm = false
+	deployPromoteConfirm = false
+
+	return CanaryDeployForm{
+		source: source,
+		log:    make([]string, 0),
+	}
+}
+
+func canaryDeployForm(source *vc.ProgramEntry, rooms vc.Rooms) *huh.Form {
+	canaries := make([]huh.Option[string], 0, len(rooms))
+	for _, r := range rooms {
+		if r.Status == string(vc.Running) {
+			canaries = append(canaries, huh.NewOption(fmt.Sprintf("%s %s", r.ID, r.Name), r.ID))
+		}
+	}
+
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Enter the local path of the new program build").
+				Prompt("📂  ").
+				Placeholder("/home/user/my_progam.cpz").
+				Validate(validateProgramFile).
+				Value(&deployOptions.AppFile),
+
+			huh.NewInput().
+				Title("Enter the friendly name of the new program entry").
+				Prompt("📛  ").
+				Placeholder(source.FriendlyName+" v2").
+				Validate(validateProgramName).
+				Value(&deployOptions.Name),
+
+			huh.NewSelect[string]().
+				Title("Select the canary room").
+				Description("the canary is moved to the new build first").
+				Options(canaries...).
+				Value(&deployCanaryId),
+
+			huh.NewSelect[vc.RetireAction]().
+				Title(fmt.Sprintf("Once every room is moved %s will be", source.FriendlyName)).
+				Options(
+					huh.NewOption("archived", vc.RetireArchive),
+					huh.NewOption("deleted", vc.RetireDelete),
+					huh.NewOption("kept", vc.RetireKeep),
+				).
+				Value(&deployRetire),
+
+			huh.NewConfirm().
+				Title("Start the deployment?").
+				Value(&deployConfirm).
+				Affirmative("Yes").
+				Negative("Cancel"),
+		),
+	).WithTheme(huh.ThemeDracula())
+}
+
+func canaryPromoteForm(d *vc.CanaryDeploy) *huh.Form {
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(fmt.Sprintf("Move the remaining %d rooms to %s?", len(d.Remaining), d.To.FriendlyName)).
+				Description("the canary health is checked until you decide, a failed check rolls the canary back").
+				Value(&deployPromoteConfirm).
+				Affirmative("Promote").
+				Negative("Rollback"),
+		),
+	).WithTheme(huh.ThemeDracula())
+}
+
+func (m CanaryDeployForm) Init() tea.Cmd {
+	return RoomsQuery
+}
+
+func (m CanaryDeployForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case error:
+		m.err = msg
+		return m, nil
+
+	case vc.Rooms:
+		if m.form != nil {
+			return m, nil
+		}
+		m.rooms = msg.ForProgram(m.source.ProgramID)
+		if len(m.rooms.WithStatus(vc.Running)) == 0 {
+			m.err = fmt.Errorf("NO RUNNING ROOMS ARE USING %s, A RUNNING CANARY ROOM IS REQUIRED", m.source.FriendlyName)
+			return m, nil
+		}
+		m.form = canaryDeployForm(m.source, m.rooms)
+		return m, m.form.Init()
+
+	case *vc.CanaryDeploy:
+		m.deploy = msg
+		m.stage = deployUpload
+		m.log = append(m.log, fmt.Sprintf("canary %s has %s devices online", msg.Canary.ID, msg.Baseline.Devices()))
+		return m, deployCmd(deployUpload, func() error {
+			return m.deploy.Upload(server, deployOptions)
+		})
+
+	case deployStep:
+		return m.updateStep(msg)
+
+	case deployHealthCheck:
+		if m.stage != deployHealth {
+			return m, nil
+		}
+		if msg.err != nil {
+			return m.rollback(msg.err)
+		}
+		m.health = &msg.health
+		m.checked = time.Now()
+		return m, canaryHealthCheck(m.deploy, deployHealthInterval)
+
+	case vc.RebindResult:
+		m.log = append(m.log, rebindMessage(msg, m.deploy.To))
+		if msg.Err != nil {
+			m.failed++
+		}
+		m.pending = m.pending[1:]
+		return m.promoteNext()
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "ctrl+q":
+			if m.stage == deployForm || m.stage == deployDone {
+				return ReturnToPrograms(), tea.Batch(tick, ProgramsQuery, ProgramUsageQuery)
+			}
+		}
+	}
+
+	if m.stage == deployHealth && m.promote != nil {
+		return m.updatePromote(msg)
+	}
+
+	if m.form == nil || m.stage != deployForm {
+		return m, nil
+	}
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+
+		if m.form.State == huh.StateCompleted {
+			if !deployConfirm {
+				return ReturnToPrograms(), tea.Batch(tick, ProgramsQuery)
+			}
+			m.stage = deployStarting
+			return m, newCanaryDeploy(*m.source, deployCanaryId)
+		}
+	}
+	return m, cmd
+}
+
+func (m CanaryDeployForm) updateStep(step deployStep) (tea.Model, tea.Cmd) {
+	switch step.stage {
+
+	case deployStarting, deployUpload:
+		if step.err != nil {
+			m.err = step.err
+			m.stage = deployDone
+			return m, nil
+		}
+		m.log = append(m.log, fmt.Sprintf("uploaded %s as program %d", m.deploy.To.FriendlyName, m.deploy.To.ProgramID))
+		m.stage = deployCanary
+		return m, func() tea.Msg {
+			return deployStep{stage: deployCanary, err: m.deploy.MoveCanary(server).Err}
+		}
+
+	case deployCanary:
+		if step.err != nil {
+			return m.rollback(step.err)
+		}
+		m.log = append(m.log, fmt.Sprintf("moved canary %s to %s, waiting for the room to start", m.deploy.Canary.ID, m.deploy.To.FriendlyName))
+		m.stage = deployWait
+		return m, deployCmd(deployWait, func() error {
+			_, err := m.deploy.WaitForCanary(server, 2*time.Minute)
+			return err
+		})
+
+	case deployWait:
+		if step.err != nil {
+			return m.rollback(step.err)
+		}
+		m.log = append(m.log, fmt.Sprintf("canary %s is running", m.deploy.Canary.ID))
+		m.stage = deployHealth
+		m.promote = canaryPromoteForm(m.deploy)
+		return m, tea.Batch(canaryHealthCheck(m.deploy, 0), m.promote.Init())
+
+	case deployRetiring:
+		m.stage = deployDone
+		if step.err != nil {
+			m.err = step.err
+			return m, nil
+		}
+		m.log = append(m.log, fmt.Sprintf("deployed %s to %d rooms, %s was %s", m.deploy.To.FriendlyName, len(m.deploy.Remaining)+1, m.deploy.From.FriendlyName, retiredMessage(deployRetire)))
+		return m, nil
+
+	case deployRollback:
+		m.stage = deployDone
+		if step.err != nil {
+			m.err = fmt.Errorf("FAILED ROLLING BACK CANARY %s: %w", m.deploy.Canary.ID, step.err)
+			return m, nil
+		}
+		m.log = append(m.log, fmt.Sprintf("moved canary %s back to %s, %s was kept for troubleshooting", m.deploy.Canary.ID, m.deploy.From.FriendlyName, m.deploy.To.FriendlyName))
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m CanaryDeployForm) updatePromote(msg tea.Msg) (tea.Model, tea.Cmd) {
+	form, cmd := m.promote.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.promote = f
+
+		if m.promote.State == huh.StateCompleted {
+			if !deployPromoteConfirm {
+				return m.rollback(fmt.Errorf("CANARY ROLLED BACK BY THE OPERATOR"))
+			}
+			m.stage = deployPromote
+			m.pending = m.deploy.Remaining
+			return m.promoteNext()
+		}
+	}
+	return m, cmd
+}
+
+// Moves the next pending room, the old program is retired once every room has moved.
+func (m CanaryDeployForm) promoteNext() (tea.Model, tea.Cmd) {
+	if len(m.pending) > 0 {
+		return m, RebindRoom(m.pending[0], m.deploy.To.ProgramID)
+	}
+	if m.failed > 0 {
+		m.stage = deployDone
+		m.err = fmt.Errorf("FAILED MOVING %d ROOMS, %s WAS NOT RETIRED", m.failed, m.deploy.From.FriendlyName)
+		return m, nil
+	}
+	m.stage = deployRetiring
+	return m, deployCmd(deployRetiring, func() error {
+		return m.deploy.Retire(server, deployRetire)
+	})
+}
+
+func (m CanaryDeployForm) rollback(reason error) (tea.Model, tea.Cmd) {
+	m.log = append(m.log, fmt.Sprintf("❌ %v", reason))
+	m.stage = deployRollback
+	return m, func() tea.Msg {
+		return deployStep{stage: deployRollback, err: m.deploy.Rollback(server).Err}
+	}
+}
+
+func (m CanaryDeployForm) View() string {
+	s := GreyedOutText.Render(fmt.Sprintf("\n🐤 Canary Deploy %s\n", m.source.FriendlyName))
+
+	if m.form == nil && m.err == nil {
+		s += "\n" + RenderMessageBox(app.width).Render("loading rooms using the program, please wait...")
+		return s
+	}
+
+	if m.stage == deployForm && m.form != nil {
+		s += "\n" + m.form.View()
+	}
+
+	if len(m.log) > 0 {
+		var progress string
+		for _, l := range m.log {
+			progress += "\n " + l
+		}
+		if m.health != nil && m.stage == deployHealth {
+			progress += fmt.Sprintf("\n canary %s healthy, %s devices online, checked %s", m.deploy.Canary.ID, m.health.Devices(), m.checked.Format(time.TimeOnly))
+		}
+		if m.stage == deployPromote && len(m.pending) > 0 {
+			progress += fmt.Sprintf("\n ⏳ moving %s, please wait...", m.pending[0].ID)
+		}
+		progress += "\n"
+		s += "\n" + RenderMessageBox(app.width).Render(progress)
+	}
+
+	if m.stage == deployHealth && m.promote != nil {
+		s += "\n" + m.promote.View()
+	}
+
+	if m.err != nil {
+		s += RenderErrorBox("error deploying program", m.err)
+	}
+
+	if m.stage == deployDone || (m.err != nil && m.stage == deployForm) {
+		s += GreyedOutText.Render("\n\n esc return")
+	}
+	return s
+}
+
+func newCanaryDeploy(source vc.ProgramEntry, canary string) tea.Cmd {
+	return func() tea.Msg {
+		d, err := vc.NewCanaryDeploy(server, source, canary)
+		if err != nil {
+			return deployStep{stage: deployStarting, err: err}
+		}
+		return d
+	}
+}
+
+func deployCmd(stage deployStage, run func() error) tea.Cmd {
+	return func() tea.Msg {
+		return deployStep{stage: stage, err: run()}
+	}
+}
+
+func canaryHealthCheck(d *vc.CanaryDeploy, wait time.Duration) tea.Cmd {
+	check := func() tea.Msg {
+		health, err := d.CheckHealth(server)
+		return deployHealthCheck{health: health, err: err}
+	}
+	if wait == 0 {
+		return check
+	}
+	return tea.Tick(wait, func(time.Time) tea.Msg {
+		return check()
+	})
+}
+
+func retiredMessage(action vc.RetireAction) string {
+	switch action {
+	case vc.RetireDelete:
+		return "deleted"
+	case vc.RetireArchive:
+		return "archived"
+	}
+	return "kept"
+}
+
+func rebindMessage(r vc.RebindResult, to vc.ProgramEntry) string {
+	switch {
+	case errors.Is(r.Err, vc.ErrDryRun):
+		return fmt.Sprintf("🧪 %s not moved to %s, dry run", r.Room.ID, to.FriendlyName)
+	case r.Err != nil:
+		return fmt.Sprintf("❌ %s %v", r.Room.ID, r.Err)
+	case r.Restarted:
+		return fmt.Sprintf("✅ %s moved to %s and restarted", r.Room.ID, to.FriendlyName)
+	}
+	return fmt.Sprintf("✅ %s moved to %s", r.Room.ID, to.FriendlyName)
+}
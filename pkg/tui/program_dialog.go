@@ -18,9 +18,27 @@ type NewProgramForm struct {
 	running  bool
 	err      error
 	edit     bool
+
+	// Restarting the rooms of an edited program is confirmed after reviewing the affected rooms.
+	program   *vc.ProgramEntry
+	impact    *vc.ProgramImpact
+	impacting bool
+	confirm   *huh.Form
+
+	// Edits are reviewed against the initial options before they are saved.
+	initial vc.ProgramOptions
+	review  *huh.Form
+	changes []vc.FieldChange
 }
 
-var programOptions *vc.ProgramOptions
+var (
+	programOptions     *vc.ProgramOptions
+	progRestartConfirm bool
+
+	// Applied when a web project file is a directory.
+	programArchiveInclude string
+	programArchiveExclude string
+)
 
 func validateProgramFile(file string) error {
 	if strings.HasSuffix(file, ".cpz") || strings.HasSuffix(file, ".zip") || strings.HasSuffix(file, ".lpz") {
@@ -30,10 +48,7 @@ func validateProgramFile(file string) error {
 }
 
 func validateProgramName(name string) error {
-	if len(name) < 5 {
-		return fmt.Errorf("NAME %s MUST HAVE AT LEAST 5 CHARATERS", name)
-	}
-	return nil
+	return vc.ValidateProgramName(name)
 }
 
 func NewProgramFormModel() NewProgramForm {
@@ -103,7 +118,7 @@ func NewProgramFormModel() NewProgramForm {
 }
 
 func EditProgramFormModel(programEntry *vc.ProgramEntry) NewProgramForm {
-	programOptions = &vc.ProgramOptions{
+	initial := vc.ProgramOptions{
 		ProgramId:     int(programEntry.ProgramID),
 		AppFile:       programEntry.AppFile,
 		Name:          programEntry.FriendlyName,
@@ -113,75 +128,102 @@ func EditProgramFormModel(programEntry *vc.ProgramEntry) NewProgramForm {
 		CwsFile:       programEntry.CwsFile,
 		StartNow:      false,
 	}
+	programOptions = &initial
+	programArchiveInclude = ""
+	programArchiveExclude = ""
 
 	p := progress.New(progress.WithDefaultGradient())
 	p.Width = app.width
 
 	return NewProgramForm{
 		edit:     true,
+		program:  programEntry,
+		initial:  initial,
 		running:  false,
 		progress: p,
-		form: huh.NewForm(
-			huh.NewGroup(
-				huh.NewInput().
-					Key("FILE").
-					Title("Enter local file path").
-					Prompt("📂  ").
-					Placeholder("/home/user/my_progam.cpz").
-					Validate(validateProgramFile).
-					Value(&programOptions.AppFile),
-
-				huh.NewInput().
-					Key("NAME").
-					Title("Enter Friendly Name").
-					Prompt("🖊  ").
-					Placeholder("My friendly program name").
-					Validate(validateProgramName).
-					Value(&programOptions.Name),
-
-				huh.NewInput().
-					Key("NOTES").
-					Title("Enter Notes").
-					Prompt("📝  ").
-					Placeholder("My seemingly pointless notes").
-					Value(&programOptions.Notes),
-
-				huh.NewInput().
-					Key("MOBILITY").
-					Title("Enter local mobile project path").
-					Prompt("📱  ").
-					Placeholder("/home/user/mobile.Core3z").
-					Value(&programOptions.MobilityFile),
-
-				huh.NewInput().
-					Key("XPANEL").
-					Title("Enter local xpanel path").
-					Prompt("❌  ").
-					Placeholder("/home/user/xpanel.ch5z").
-					Value(&programOptions.WebxPanelFile),
-
-				huh.NewInput().
-					Key("TOUCHPANEL").
-					Title("Enter local touch panel project path").
-					Prompt("📲  ").
-					Placeholder("/home/user/mytp.vtz").
-					Value(&programOptions.ProjectFile),
-
-				huh.NewInput().
-					Key("CONFIGURATION").
-					Title("Enter local configuration webpage path").
-					Prompt("⚙  ").
-					Placeholder("/home/user/my_dist.zip").
-					Value(&programOptions.CwsFile),
-
-				huh.NewConfirm().
-					Title("Would you like to restart effected rooms?").
-					Value(&programOptions.StartNow),
-			),
-		).WithTheme(huh.ThemeDracula()),
+		form:     editProgramForm(),
 	}
 }
 
+// Creates the edit form using the current program options, the values are kept when returning from the review.
+func editProgramForm() *huh.Form {
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Key("FILE").
+				Title("Enter local file path").
+				Prompt("📂  ").
+				Placeholder("/home/user/my_progam.cpz").
+				Validate(validateProgramFile).
+				Value(&programOptions.AppFile),
+
+			huh.NewInput().
+				Key("NAME").
+				Title("Enter Friendly Name").
+				Prompt("🖊  ").
+				Placeholder("My friendly program name").
+				Validate(validateProgramName).
+				Value(&programOptions.Name),
+
+			huh.NewInput().
+				Key("NOTES").
+				Title("Enter Notes").
+				Prompt("📝  ").
+				Placeholder("My seemingly pointless notes").
+				Value(&programOptions.Notes),
+
+			huh.NewInput().
+				Key("MOBILITY").
+				Title("Enter local mobile project path").
+				Prompt("📱  ").
+				Placeholder("/home/user/mobile.Core3z").
+				Value(&programOptions.MobilityFile),
+
+			huh.NewInput().
+				Key("XPANEL").
+				Title("Enter local xpanel path").
+				Prompt("❌  ").
+				Placeholder("/home/user/xpanel.ch5z").
+				Value(&programOptions.WebxPanelFile),
+
+			huh.NewInput().
+				Key("TOUCHPANEL").
+				Title("Enter local touch panel project path").
+				Prompt("📲  ").
+				Placeholder("/home/user/mytp.vtz").
+				Value(&programOptions.ProjectFile),
+
+			huh.NewInput().
+				Key("CONFIGURATION").
+				Title("Enter local configuration webpage path").
+				Prompt("⚙  ").
+				Placeholder("/home/user/my_dist.zip").
+				Description("web project files may be a directory, the directory is packaged before it is uploaded").
+				Value(&programOptions.CwsFile),
+
+			huh.NewInput().
+				Key("INCLUDE").
+				Title("Include patterns").
+				Prompt("📦  ").
+				Placeholder("*.html, *.js, assets/*").
+				Description("comma separated, only files matching a pattern are packaged, empty packages every file").
+				Value(&programArchiveInclude),
+
+			huh.NewInput().
+				Key("EXCLUDE").
+				Title("Exclude patterns").
+				Prompt("🚫  ").
+				Placeholder(strings.Join(vc.DefaultArchiveExclude, ", ")).
+				Description("comma separated, hidden files are excluded when empty").
+				Value(&programArchiveExclude),
+
+			huh.NewConfirm().
+				Title("Would you like to restart effected rooms?").
+				Value(&programOptions.StartNow),
+		),
+	).WithTheme(huh.ThemeDracula())
+}
+
 func (m NewProgramForm) Init() tea.Cmd {
 	return m.form.Init()
 }
@@ -200,6 +242,20 @@ func (m NewProgramForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		//}
 		return m, nil
 
+	case vc.ProgramImpact:
+		m.impact = &msg
+		if len(msg.Rooms) == 0 || (!programOptions.StartNow && impactProtection(msg, vc.ProtectEditProgram) == nil) {
+			m.running = true
+			return m, tea.Batch(SumbitNewProgramForm(&m), programUploadTickCmd())
+		}
+		progRestartConfirm = false
+		title := fmt.Sprintf("Upload %s and restart the rooms using this program?", programOptions.Name)
+		if !programOptions.StartNow {
+			title = fmt.Sprintf("Upload %s to a program used by protected rooms?", programOptions.Name)
+		}
+		m.confirm = impactConfirmationForm(msg, vc.ProtectEditProgram, title, &progRestartConfirm)
+		return m, m.confirm.Init()
+
 	case vc.ProgramUploadResult:
 		m.result = &msg
 		return m, m.progress.IncrPercent(1.0)
@@ -228,11 +284,79 @@ func (m NewProgramForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	if m.confirm != nil && !m.running {
+		return m.updateConfirm(msg)
+	}
+
+	if m.review != nil && !m.impacting && !m.running {
+		return m.updateReview(msg)
+	}
+
 	form, cmd := m.form.Update(msg)
 	if f, ok := form.(*huh.Form); ok {
 		m.form = f
 
 		if m.form.State == huh.StateCompleted && !m.running {
+			if m.edit {
+				if m.review != nil {
+					return m, cmd
+				}
+				initial := m.initial
+				initial.StartNow = programOptions.StartNow
+				m.changes = vc.DiffFields(initial, *programOptions)
+				m.review = reviewForm(m.changes)
+				return m, m.review.Init()
+			}
+
+			m.running = true
+			return m, tea.Batch(SumbitNewProgramForm(&m), programUploadTickCmd())
+		}
+	}
+	return m, cmd
+}
+
+// Saves the reviewed changes or returns to the edit form with the edited values.
+func (m NewProgramForm) updateReview(msg tea.Msg) (tea.Model, tea.Cmd) {
+	form, cmd := m.review.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.review = f
+
+		if m.review.State == huh.StateCompleted {
+			if !reviewSave {
+				m.review = nil
+				m.form = editProgramForm()
+				return m, m.form.Init()
+			}
+
+			if programOptions.StartNow || policyEnforced() {
+				m.impacting = true
+				return m, ProgramImpactQuery(*m.program)
+			}
+
+			m.running = true
+			return m, tea.Batch(SumbitNewProgramForm(&m), programUploadTickCmd())
+		}
+	}
+	return m, cmd
+}
+
+// The warning displayed in the review when saving the program restarts rooms.
+func programRestartWarning(program *vc.ProgramEntry) string {
+	if !programOptions.StartNow {
+		return ""
+	}
+	return fmt.Sprintf("rooms using %s will be restarted when the program is saved", program.FriendlyName)
+}
+
+func (m NewProgramForm) updateConfirm(msg tea.Msg) (tea.Model, tea.Cmd) {
+	form, cmd := m.confirm.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.confirm = f
+
+		if m.confirm.State == huh.StateCompleted {
+			if !impactConfirmed(*m.impact, vc.ProtectEditProgram, progRestartConfirm) {
+				return ReturnToPrograms(), tea.Batch(tick, ProgramsQuery)
+			}
 			m.running = true
 			return m, tea.Batch(SumbitNewProgramForm(&m), programUploadTickCmd())
 		}
@@ -248,7 +372,21 @@ func (m NewProgramForm) View() string {
 		s += GreyedOutText.Render("\n🆕 Create New Program Entry\n")
 	}
 
-	s += "\n" + m.form.View()
+	if m.impact != nil && m.confirm != nil {
+		s += "\n" + renderProgramImpact(*m.impact, vc.ProtectEditProgram, app.width)
+		if !m.running {
+			s += "\n" + m.confirm.View()
+		}
+	} else if m.impacting && m.impact == nil && m.err == nil {
+		s += "\n" + RenderMessageBox(app.width).Render("loading rooms using the program, please wait...")
+	} else if m.review != nil {
+		s += "\n" + renderReview(m.changes, programRestartWarning(m.program), app.width)
+		if !m.running {
+			s += "\n" + m.review.View()
+		}
+	} else {
+		s += "\n" + m.form.View()
+	}
 
 	if m.progress.Percent() != 0.0 {
 		s += "\n" + m.progress.View() + "\n\n"
@@ -283,12 +421,26 @@ func SumbitNewProgramForm(m *NewProgramForm) tea.Cmd {
 	}
 	return func() tea.Msg {
 		if m.edit {
-			return EditProgram(*programOptions)
+			options := *programOptions
+			options.ArchiveInclude = splitPatterns(programArchiveInclude)
+			options.ArchiveExclude = splitPatterns(programArchiveExclude)
+			return EditProgram(options)
 		}
 		return CreateNewProgram(*programOptions)
 	}
 }
 
+// Splits comma separated archive patterns, nil is returned for an empty value so the default patterns are used.
+func splitPatterns(value string) []string {
+	var patterns []string
+	for _, p := range strings.Split(value, ",") {
+		if p = strings.TrimSpace(p); len(p) > 0 {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
 func programUploadTickCmd() tea.Cmd {
 	return tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg {
 		return progressTick(t)
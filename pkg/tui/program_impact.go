@@ -0,0 +1,135 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+var (
+	impactConfirmName string
+)
+
+// Loads the rooms affected by changing the program.
+func ProgramImpactQuery(program vc.ProgramEntry) tea.Cmd {
+	return func() tea.Msg {
+		impact, err := vc.NewProgramImpact(server, program)
+		if err != nil {
+			return err
+		}
+		return impact
+	}
+}
+
+// The name the operator must type to confirm a change to a program with running or production rooms.
+func impactConfirmationName(program vc.ProgramEntry) string {
+	if len(program.FriendlyName) > 0 {
+		return program.FriendlyName
+	}
+	return program.ProgramName
+}
+
+// Returns the policy protecting the rooms using the program, nil when the action doesn't need to be confirmed.
+func impactProtection(impact vc.ProgramImpact, action string) *vc.ProtectedError {
+	rooms := make([]vc.Room, 0, len(impact.Rooms))
+	for _, r := range impact.Rooms {
+		rooms = append(rooms, r.Room)
+	}
+	return vc.CheckPolicy(server, action, impactConfirmationName(impact.Program), rooms...)
+}
+
+// Returns true when the policy protects the rooms using the program, the upload must be confirmed even when no room restarts.
+func policyEnforced() bool {
+	_, ok := server.(*vc.PolicyVC)
+	return ok
+}
+
+// Creates the confirmation for a program change.
+// Running, production, or protected rooms require the program name to be typed, otherwise a yes/no confirmation is used.
+func impactConfirmationForm(impact vc.ProgramImpact, action string, title string, confirmed *bool) *huh.Form {
+	impactConfirmName = ""
+	name := impactConfirmationName(impact.Program)
+
+	if impact.RequiresTypedConfirmation() || impactProtection(impact, action) != nil {
+		return huh.NewForm(
+			huh.NewGroup(
+				huh.NewInput().
+					Title(title).
+					Description(fmt.Sprintf("type %s to confirm, esc to cancel", name)).
+					Prompt("⚠  ").
+					Validate(func(s string) error {
+						if s != name {
+							return fmt.Errorf("TYPE %s TO CONFIRM", name)
+						}
+						return nil
+					}).
+					Value(&impactConfirmName),
+			),
+		).WithTheme(huh.ThemeDracula())
+	}
+
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(title).
+				Value(confirmed).
+				Affirmative("Yes").
+				Negative("Cancel"),
+		),
+	).WithTheme(huh.ThemeDracula())
+}
+
+// Returns true when the operator confirmed the change using the form created by impactConfirmationForm.
+// A change protected by the policy is approved for a single call once the name is typed.
+func impactConfirmed(impact vc.ProgramImpact, action string, confirmed bool) bool {
+	if protection := impactProtection(impact, action); protection != nil {
+		if impactConfirmName != protection.Target {
+			return false
+		}
+		vc.ApprovePolicy(server, action, protection.Target)
+		return true
+	}
+	if impact.RequiresTypedConfirmation() {
+		return impactConfirmName == impactConfirmationName(impact.Program)
+	}
+	return confirmed
+}
+
+// Renders the rooms bound to the program with the devices that will be disconnected.
+func renderProgramImpact(impact vc.ProgramImpact, action string, width int) string {
+	if protection := impactProtection(impact, action); protection != nil {
+		return renderImpactRooms(impact, width) + "\n" + renderProtection(protection, width)
+	}
+	return renderImpactRooms(impact, width)
+}
+
+func renderImpactRooms(impact vc.ProgramImpact, width int) string {
+	if len(impact.Rooms) == 0 {
+		return RenderMessageBox(width).Render(fmt.Sprintf("no rooms are using %s", impact.Program.FriendlyName))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d ROOMS ARE USING %s, %d RUNNING, %d PRODUCTION\n\n",
+		len(impact.Rooms), impact.Program.FriendlyName, impact.Running(), impact.Production())
+
+	w := tabwriter.NewWriter(&b, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "ROOM\tSTATUS\tDEVICES ONLINE\tDEBUG\tTAGS")
+	for _, r := range impact.Rooms {
+		tags := make([]string, 0)
+		for _, tag := range r.Room.Tags() {
+			tags = append(tags, "#"+tag)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\n", r.Room.ID, r.Room.Status, r.Devices(), r.Room.Debugging, strings.Join(tags, " "))
+	}
+	w.Flush()
+
+	if impact.RequiresTypedConfirmation() {
+		return RenderWarningBox(width).Render(b.String())
+	}
+	return RenderMessageBox(width).Render(b.String())
+}
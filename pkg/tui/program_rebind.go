@@ -0,0 +1,189 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+type RebindProgramForm struct {
+	source   *vc.ProgramEntry
+	programs vc.Programs
+	rooms    vc.Rooms
+	form     *huh.Form
+	pending  vc.Rooms
+	results  []vc.RebindResult
+	running  bool
+	err      error
+}
+
+var (
+	rebindTarget  vc.ProgramEntry
+	rebindRooms   []vc.Room
+	rebindConfirm bool
+)
+
+// Moves the rooms using the program to a different program, running rooms are restarted one at a time.
+// The form is created once the programs and rooms have been loaded.
+func RebindProgramFormModel(source *vc.ProgramEntry) RebindProgramForm {
+	rebindTarget = vc.ProgramEntry{}
+	rebindRooms = make([]vc.Room, 0)
+	rebindConfirm = false
+
+	return RebindProgramForm{
+		source:  source,
+		results: make([]vc.RebindResult, 0),
+	}
+}
+
+func rebindProgramForm(source *vc.ProgramEntry, programs vc.Programs, rooms vc.Rooms) *huh.Form {
+	progs := make([]huh.Option[vc.ProgramEntry], 0, len(programs))
+	for _, p := range programs {
+		if p.ProgramID == source.ProgramID {
+			continue
+		}
+		progs = append(progs, huh.NewOption(fmt.Sprintf("%s (%s)", p.FriendlyName, p.AppFile), p))
+	}
+
+	options := make([]huh.Option[vc.Room], 0, len(rooms))
+	for _, r := range rooms {
+		options = append(options, huh.NewOption(fmt.Sprintf("%s %s (%s)", r.ID, r.Name, r.Status), r).Selected(true))
+	}
+
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[vc.ProgramEntry]().
+				Title(fmt.Sprintf("Select the program the rooms using %s will run", source.FriendlyName)).
+				Options(progs...).
+				Value(&rebindTarget),
+
+			huh.NewMultiSelect[vc.Room]().
+				Title("Select the rooms to move").
+				Description("running rooms are restarted one at a time").
+				Options(options...).
+				Value(&rebindRooms),
+
+			huh.NewConfirm().
+				Title("Move the selected rooms?").
+				Value(&rebindConfirm).
+				Affirmative("Yes").
+				Negative("Cancel"),
+		),
+	).WithTheme(huh.ThemeDracula())
+}
+
+func (m RebindProgramForm) Init() tea.Cmd {
+	return tea.Batch(ProgramsQuery, RoomsQuery)
+}
+
+func (m RebindProgramForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case error:
+		m.err = msg
+		return m, nil
+
+	case vc.Programs:
+		m.programs = msg
+		return m.createForm()
+
+	case vc.Rooms:
+		m.rooms = msg.ForProgram(m.source.ProgramID)
+		return m.createForm()
+
+	case vc.RebindResult:
+		m.results = append(m.results, msg)
+		m.pending = m.pending[1:]
+		if len(m.pending) > 0 {
+			return m, RebindRoom(m.pending[0], rebindTarget.ProgramID)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "ctrl+q":
+			if !m.running || len(m.pending) == 0 {
+				return ReturnToPrograms(), tea.Batch(tick, ProgramsQuery, ProgramUsageQuery)
+			}
+		}
+	}
+
+	if m.form == nil || m.running {
+		return m, nil
+	}
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+
+		if m.form.State == huh.StateCompleted {
+			if !rebindConfirm || len(rebindRooms) == 0 {
+				return ReturnToPrograms(), tea.Batch(tick, ProgramsQuery)
+			}
+			m.running = true
+			m.pending = rebindRooms
+			return m, RebindRoom(m.pending[0], rebindTarget.ProgramID)
+		}
+	}
+	return m, cmd
+}
+
+// Creates the form once both the programs and the rooms have been loaded.
+func (m RebindProgramForm) createForm() (tea.Model, tea.Cmd) {
+	if m.programs == nil || m.rooms == nil || m.form != nil {
+		return m, nil
+	}
+	if len(m.rooms) == 0 {
+		m.err = fmt.Errorf("NO ROOMS ARE USING %s", m.source.FriendlyName)
+		return m, nil
+	}
+	if len(m.programs) < 2 {
+		m.err = fmt.Errorf("THERE ARE NO OTHER PROGRAMS LOADED TO THE SYSTEM, CREATE A PROGRAM BEFORE MOVING ROOMS")
+		return m, nil
+	}
+	m.form = rebindProgramForm(m.source, m.programs, m.rooms)
+	return m, m.form.Init()
+}
+
+func (m RebindProgramForm) View() string {
+	s := GreyedOutText.Render(fmt.Sprintf("\n🔗 Move Rooms From %s\n", m.source.FriendlyName))
+
+	if m.err != nil {
+		s += RenderErrorBox("error moving rooms", m.err)
+		s += GreyedOutText.Render("\n\n esc return")
+		return s
+	}
+
+	if m.form == nil {
+		s += "\n" + RenderMessageBox(app.width).Render("loading rooms and programs, please wait...")
+		return s
+	}
+
+	if !m.running {
+		s += "\n" + m.form.View()
+		return s
+	}
+
+	var resultMessage string
+	for _, r := range m.results {
+		resultMessage += "\n " + rebindMessage(r, rebindTarget)
+	}
+	if len(m.pending) > 0 {
+		resultMessage += fmt.Sprintf("\n ⏳ moving %s, please wait...", m.pending[0].ID)
+	}
+	resultMessage += "\n"
+
+	s += "\n" + RenderMessageBox(app.width).Render(resultMessage)
+	if len(m.pending) == 0 {
+		s += GreyedOutText.Render("\n\n esc return")
+	}
+	return s
+}
+
+func RebindRoom(room vc.Room, programId int16) tea.Cmd {
+	return func() tea.Msg {
+		return vc.RebindRoom(server, room, programId)
+	}
+}
@@ -15,6 +15,7 @@ import (
 type ProgramsModel struct {
 	table         table.Model
 	Programs      vc.Programs
+	usage         vc.ProgramUsage
 	selected      vc.ProgramEntry
 	err           error
 	help          programsHelpModel
@@ -22,11 +23,12 @@ type ProgramsModel struct {
 	cursor        int
 	banner        *BannerModel
 	width, height int
+	yank          yanker
 }
 
 func InitialProgramsModel(width, height int) *ProgramsModel {
 	programsView = &ProgramsModel{
-		table:    newProgramsTable(make(vc.Programs, 0), 0, width),
+		table:    newProgramsTable(make(vc.Programs, 0), nil, 0, width),
 		Programs: vc.Programs{},
 		selected: vc.ProgramEntry{},
 		cursor:   0,
@@ -58,7 +60,7 @@ func BusyProgramsModel(b busy, Programs vc.Programs) *ProgramsModel {
 }
 
 func (m ProgramsModel) Init() tea.Cmd {
-	return ProgramsQuery
+	return tea.Batch(ProgramsQuery, ProgramUsageQuery)
 }
 
 func (m ProgramsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -72,7 +74,7 @@ func (m ProgramsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.width = w
 			m.height = h
 		}
-		return m, tea.Batch(ProgramsQuery, tick)
+		return m, tea.Batch(ProgramsQuery, ProgramUsageQuery, tick)
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -95,11 +97,16 @@ func (m ProgramsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.busy = msg
 		return m, nil
 
+	case vc.ProgramUsage:
+		m.usage = msg
+		m.table.SetRows(getProgramRows(m.width, m.cursor, m.Programs, m.usage))
+		return m, nil
+
 	case vc.Programs:
 		m.busy = busy{flag: false}
 		m.Programs = msg
 		if len(msg) > 0 {
-			m.table = newProgramsTable(msg, m.cursor, m.width)
+			m.table = newProgramsTable(msg, m.usage, m.cursor, m.width)
 			m.selected = msg[m.cursor]
 			return m, nil
 		}
@@ -110,6 +117,12 @@ func (m ProgramsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return NewProgramsErrorTable(msg), nil
 
 	case tea.KeyMsg:
+		if m.yank.handle(msg, getProgramColumns(m.width), m.table.SelectedRow(), m.selected) {
+			return m, nil
+		}
+		if readOnlyKey(msg, programsMutatingKeys) {
+			return m, nil
+		}
 		switch msg.String() {
 
 		case "ctrl+q", "q", "ctrl+c", "esc":
@@ -137,16 +150,33 @@ func (m ProgramsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return form, form.Init()
 			}
 
+		case "e":
+			if m.err == nil && len(m.Programs) > 0 {
+				editor := ProgramEditorModel(&m.selected)
+				return editor, editor.Init()
+			}
+
 		case "ctrl+d", "delete":
 			if m.err == nil {
 				if m.cursor == len(m.Programs) {
 					m.cursor = m.cursor - 1
 				}
 				if len(m.Programs) > 0 {
-					return DeleteProgramFormModel(&m.selected), nil
+					form := DeleteProgramFormModel(&m.selected)
+					return form, form.Init()
 				}
 				return m, nil
 			}
+		case "ctrl+b":
+			if m.err == nil && len(m.Programs) > 0 {
+				form := RebindProgramFormModel(&m.selected)
+				return form, form.Init()
+			}
+		case "ctrl+u":
+			if m.err == nil && len(m.Programs) > 0 {
+				form := CanaryDeployFormModel(&m.selected)
+				return form, form.Init()
+			}
 		case "ctrl+r":
 			if m.err == nil {
 				if m.cursor == len(m.Programs) {
@@ -169,6 +199,9 @@ func (m ProgramsModel) View() string {
 
 	if m.busy.flag {
 		s += RenderMessageBox(m.width).Render(m.busy.message)
+	} else if m.err == nil && ReadOnly {
+		prog := fmt.Sprintf("\u2192 read only, %s %s can be viewed but not changed\n", m.selected.FriendlyName, m.selected.AppFile)
+		s += RenderMessageBox(m.width).Render(prog)
 	} else if m.err == nil {
 		prog := fmt.Sprintf("\u2192 use keyboard actions to manage %s %s (ctrl+s, ctrl+d...)\n", m.selected.FriendlyName, m.selected.AppFile)
 		s += RenderMessageBox(m.width).Render(prog)
@@ -179,14 +212,15 @@ func (m ProgramsModel) View() string {
 		}
 	}
 
+	s += m.yank.View(getProgramColumns(m.width))
 	s += m.help.renderHelpInfo()
 	return s
 }
 
-func newProgramsTable(Programs vc.Programs, cursor int, width int) table.Model {
+func newProgramsTable(Programs vc.Programs, usage vc.ProgramUsage, cursor int, width int) table.Model {
 
 	columns := getProgramColumns(width)
-	rows := getProgramRows(width, cursor, Programs)
+	rows := getProgramRows(width, cursor, Programs, usage)
 
 	t := table.New(
 		table.WithColumns(columns),
@@ -220,31 +254,33 @@ func getProgramColumns(width int) []table.Column {
 			{Title: "", Width: 1},
 			{Title: "Name", Width: 20},
 			{Title: "App File", Width: 35},
-			{Title: "Notes", Width: width - 80},
+			{Title: "Notes", Width: width - 92},
 			{Title: "Type", Width: 12},
+			{Title: "Rooms", Width: 10},
 		}
 	}
 	return []table.Column{
 		{Title: "", Width: 1},
 		{Title: "Name", Width: 20},
 		{Title: "App File", Width: 35},
-		{Title: "Notes", Width: width - 154},
+		{Title: "Notes", Width: width - 166},
 		{Title: "Type", Width: 16},
+		{Title: "Rooms", Width: 10},
 		{Title: "Compiled", Width: 32},
 		{Title: "Crestron DB", Width: 16},
 		{Title: "Device DB", Width: 16},
 	}
 }
 
-func getProgramRows(width int, cursor int, Programs vc.Programs) []table.Row {
+func getProgramRows(width int, cursor int, Programs vc.Programs, usage vc.ProgramUsage) []table.Row {
 	rows := []table.Row{}
 	small := width < 200
 
 	if len(Programs) == 0 {
 		if small {
-			rows = append(rows, table.Row{"", "No programs loaded to system, press ctl+n to a new program", "", "", "ctrl+n"})
+			rows = append(rows, table.Row{"", "No programs loaded to system, press ctl+n to a new program", "", "", "", "ctrl+n"})
 		} else {
-			rows = append(rows, table.Row{"", "No programs loaded to system, press ctl+n to a new program", "", "", "", "", "", "ctrl+n"})
+			rows = append(rows, table.Row{"", "No programs loaded to system, press ctl+n to a new program", "", "", "", "", "", "", "ctrl+n"})
 		}
 	}
 
@@ -253,15 +289,40 @@ func getProgramRows(width int, cursor int, Programs vc.Programs) []table.Row {
 		if cursor == i {
 			marker = "\u2192"
 		}
+		rooms := getProgramUsage(usage, prog)
 		if small {
-			rows = append(rows, table.Row{marker, prog.FriendlyName, prog.AppFile, prog.Notes, prog.ProgramType})
+			rows = append(rows, table.Row{marker, prog.FriendlyName, prog.AppFile, programNotes(prog), prog.ProgramType, rooms})
 		} else {
-			rows = append(rows, table.Row{marker, prog.FriendlyName, prog.AppFile, prog.Notes, prog.ProgramType, prog.CompileDateTime, prog.CresDBVersion, prog.DeviceDBVersion})
+			rows = append(rows, table.Row{marker, prog.FriendlyName, prog.AppFile, programNotes(prog), prog.ProgramType, rooms, prog.CompileDateTime, prog.CresDBVersion, prog.DeviceDBVersion})
 		}
 	}
 	return rows
 }
 
+// Displays the notes with the provenance stamp shortened to the uploaded file hash.
+func programNotes(prog vc.ProgramEntry) string {
+	p, ok := prog.Provenance()
+	if !ok {
+		return prog.Notes
+	}
+	notes := vc.StripProvenance(prog.Notes)
+	if len(notes) == 0 {
+		return "sha " + p.ShortSHA()
+	}
+	return notes + " · sha " + p.ShortSHA()
+}
+
+// Displays the number of rooms using the program or an unused marker, nothing is displayed until the rooms are loaded.
+func getProgramUsage(usage vc.ProgramUsage, prog vc.ProgramEntry) string {
+	if usage == nil {
+		return ""
+	}
+	if usage[prog.ProgramID] == 0 {
+		return UnusedMarker + " unused"
+	}
+	return fmt.Sprintf("%d", usage[prog.ProgramID])
+}
+
 func NewProgramsErrorTable(msg vc.VirtualControlError) ProgramsModel {
 	columns := []table.Column{
 		{Title: "SERVER ERROR", Width: 20},
@@ -307,6 +368,15 @@ func ProgramsQuery() tea.Msg {
 	return programs
 }
 
+func ProgramUsageQuery() tea.Msg {
+
+	rooms, err := server.GetRooms()
+	if err != nil {
+		return err
+	}
+	return vc.NewProgramUsage(rooms)
+}
+
 func CreateNewProgram(options vc.ProgramOptions) tea.Msg {
 
 	result, err := server.CreateProgram(options)
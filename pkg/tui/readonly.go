@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+// The keys of each view changing the appliance, the keys are ignored in read only mode.
+var (
+	roomsMutatingKeys    = []string{"ctrl+s", "ctrl+r", "ctrl+d", "ctrl+e", "enter", "e", "delete", "ctrl+b", "ctrl+o", "ctrl+g", "ctrl+n"}
+	programsMutatingKeys = []string{"ctrl+n", "ctrl+e", "enter", "e", "ctrl+d", "delete", "ctrl+b", "ctrl+u", "ctrl+r"}
+	tokensMutatingKeys   = []string{"ctrl+d", "delete", "ctrl+n", "n", "ctrl+e", "enter"}
+	serviceMutatingKeys  = []string{"s", "ctrl+s", "n", "ctrl+n", "r", "ctrl+r"}
+)
+
+// The reason read only mode was enabled when the token couldn't be looked up, rendered in the read only badge.
+var readOnlyReason string
+
+// Enables read only mode when the token is listed by the appliance as a read only token.
+// When the token list can't be loaded vcli can't tell the token apart from a read only token,
+// read only mode is enabled and the error is returned so it can be reported.
+// Local connections don't use a token and are never read only, the mode is then only enabled with -read-only.
+func DetectReadOnly(server vc.VirtualControl) error {
+	var err error
+	if !ReadOnly && len(Token) > 0 {
+		var tokens []vc.ApiToken
+		tokens, err = server.GetTokens()
+		status, ok := vc.TokenAccess(tokens, Token)
+		ReadOnly = err != nil || (ok && status == vc.ReadOnlyToken)
+		if err != nil {
+			err = fmt.Errorf("FAILED LOADING THE TOKENS, READ ONLY MODE IS ENABLED: %w", err)
+			readOnlyReason = "the token couldn't be looked up"
+		}
+	}
+	if ReadOnly {
+		disableMutatingKeys()
+	}
+	return err
+}
+
+// Checked by the client on each request, the mode is enabled once the token has been looked up.
+func readOnlyMode() bool {
+	return ReadOnly
+}
+
+// Disables the bindings of the mutating actions, disabled bindings are hidden from the help of each view.
+func disableMutatingKeys() {
+	bindings := []*key.Binding{
+		&roomKeys.Start, &roomKeys.Stop, &roomKeys.Restart, &roomKeys.Debug, &roomKeys.Create, &roomKeys.Delete,
+		&roomKeys.Edit, &roomKeys.Rebind, &roomKeys.Clone, &roomKeys.Wizard, &roomKeys.Yaml,
+		&programKeys.New, &programKeys.Delete, &programKeys.Edit, &programKeys.Room, &programKeys.Rebind,
+		&programKeys.Deploy, &programKeys.Yaml,
+		&tokenKeys.Create, &tokenKeys.Edit, &tokenKeys.Delete,
+		&serviceKeys.Start, &serviceKeys.Stop, &serviceKeys.Restart,
+	}
+	for _, b := range bindings {
+		b.SetEnabled(false)
+	}
+}
+
+// Returns true when the key is one of the mutating keys of the view and vcli is read only.
+func readOnlyKey(msg tea.KeyMsg, keys []string) bool {
+	return ReadOnly && slices.Contains(keys, msg.String())
+}
+
+// Renders the read only badge, nothing is rendered unless the token is read only or -read-only was provided.
+func renderReadOnly(width int) string {
+	if !ReadOnly {
+		return ""
+	}
+
+	badge := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFFFF")).
+		Background(lipgloss.Color(WarningColor)).
+		Padding(0, 2).
+		Render(strings.TrimSuffix("READ ONLY, "+readOnlyReason, ", "))
+	return lipgloss.PlaceHorizontal(width, lipgloss.Center, badge) + "\n"
+}
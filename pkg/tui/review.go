@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+var reviewSave bool
+
+// Asks the operator to save the reviewed changes or return to the edit form.
+func reviewForm(changes []vc.FieldChange) *huh.Form {
+	reviewSave = true
+
+	title := fmt.Sprintf("Save %d changes?", len(changes))
+	if len(changes) == 0 {
+		title = "Nothing was changed, save anyway?"
+	}
+
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(title).
+				Value(&reviewSave).
+				Affirmative("Save").
+				Negative("Edit"),
+		),
+	).WithTheme(huh.ThemeDracula())
+}
+
+// Renders the changed fields, uploaded files are highlighted and the restart warning is displayed below the changes.
+func renderReview(changes []vc.FieldChange, restart string, width int) string {
+	s := "\n REVIEW CHANGES\n"
+	if len(changes) == 0 {
+		s += "\n no fields were changed\n"
+	}
+
+	for _, c := range changes {
+		if c.File() {
+			s += "\n " + HighlightedText.Render(fmt.Sprintf("📂 %s: %q will be uploaded", c.Field, c.New))
+			continue
+		}
+		s += "\n    " + c.String()
+	}
+
+	review := RenderMessageBox(width).Height(lipgloss.Height(s) + 1).Render(s + "\n")
+	if len(restart) > 0 {
+		return review + "\n" + RenderWarningBox(width).Render(restart)
+	}
+	return review + "\n" + RenderMessageBox(width).Render("no rooms will be restarted")
+}
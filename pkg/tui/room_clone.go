@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/huh"
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+// Creates a room form pre filled with the configuration of an existing room.
+// The room ID must be unique, the user file must be provided from a local copy.
+func CloneRoomFormModel(room *vc.Room, rooms vc.Rooms) NewRoomForm {
+	options := vc.NewRoomOptionsFromClone(*room, "", room.Name)
+	roomOptions = &options
+	selectProg = vc.ProgramEntry{
+		ProgramID:    room.ProgramID,
+		ProgramName:  room.ProgramName,
+		FriendlyName: room.ProgramFriendly,
+	}
+
+	validateCloneId := func(id string) error {
+		if err := validateRoomId(id); err != nil {
+			return err
+		}
+		if existing, _ := rooms.WithIDs([]string{id}); len(existing) > 0 {
+			return fmt.Errorf("ROOM %s ALREADY EXISTS", id)
+		}
+		return nil
+	}
+
+	userFile := "the user file is uploaded to the new room"
+	if len(room.UserFile) > 0 {
+		userFile = fmt.Sprintf("%s uses %s, the file can't be copied, provide a local copy to upload it", room.ID, room.UserFile)
+	}
+
+	p := progress.New(progress.WithDefaultGradient())
+	p.Width = app.width
+
+	return NewRoomForm{
+		progress: p,
+		running:  false,
+		form: huh.NewForm(
+			huh.NewGroup(
+
+				huh.NewSelect[vc.ProgramEntry]().
+					Title("Select a program").
+					Options(huh.NewOption[vc.ProgramEntry](room.ProgramName, selectProg)).
+					Value(&selectProg).
+					Description(fmt.Sprintf("cloning %s running %s", room.ID, room.ProgramFriendly)),
+
+				huh.NewInput().
+					Key("NAME").
+					Title("Enter Friendly Name").
+					Prompt("📛  ").
+					Placeholder(room.Name).
+					Validate(validateRoomName).
+					Value(&roomOptions.Name),
+
+				huh.NewInput().
+					Key("ROOM ID").
+					Title("Enter Room ID").
+					Prompt("🆔  ").
+					Placeholder("ROOM404").
+					Validate(validateCloneId).
+					Value(&roomOptions.ProgramInstanceId),
+
+				huh.NewInput().
+					Key("NOTES").
+					Title("Enter Notes").
+					Prompt("📝  ").
+					Placeholder("My seemingly pointless notes").
+					Value(&roomOptions.Notes),
+
+				huh.NewInput().
+					Key("ADDRESS").
+					Title("Location").
+					Prompt("🏚  ").
+					Placeholder("404 Bad Address Location").
+					Value(&roomOptions.Location),
+
+				huh.NewConfirm().
+					Title("Address Sets Location").
+					Value(&roomOptions.AddressSetsLocation),
+
+				huh.NewInput().
+					Key("TIMEZONE").
+					Title("Time Zone").
+					Prompt("⏲  ").
+					Placeholder("+/- numeric value").
+					Value(&roomOptions.TimeZone),
+
+				huh.NewInput().
+					Key("LAT").
+					Title("Latitude").
+					Prompt("🌐  ").
+					Placeholder("39.352862").
+					Value(&roomOptions.Latitude),
+
+				huh.NewInput().
+					Key("LONG").
+					Title("Longitude").
+					Prompt("🌐  ").
+					Placeholder("-76.407341").
+					Value(&roomOptions.Longitude),
+
+				huh.NewInput().
+					Key("USER_FILE").
+					Title("Upload User File").
+					Description(userFile).
+					Prompt("👤  ").
+					Placeholder("/home/user/myconfig.json").
+					Validate(validateUserFile).
+					Value(&roomOptions.UserFile),
+			),
+		).WithTheme(huh.ThemeDracula()),
+	}
+}
@@ -11,6 +11,8 @@ import (
 type DeleteRoomForm struct {
 	room *vc.Room
 	form *huh.Form
+	// The policy protecting the room, the room ID must be typed instead of confirmed.
+	protection *vc.ProtectedError
 }
 
 var (
@@ -18,6 +20,11 @@ var (
 )
 
 func DeleteRoomFormModel(room *vc.Room) DeleteRoomForm {
+	roomDeleteConfirm = false
+	if protection := vc.CheckPolicy(server, vc.ProtectDelete, room.ID, *room); protection != nil {
+		return DeleteRoomForm{room: room, form: protectedConfirmForm(protection), protection: protection}
+	}
+
 	return DeleteRoomForm{
 		room: room,
 		form: huh.NewForm(
@@ -42,15 +49,26 @@ func (m DeleteRoomForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "shift+tab", "ctrl+q":
 			return ReturnRoomsModel(), tea.Batch(RoomsQuery, tick)
+		case "esc":
+			if m.protection != nil {
+				return ReturnRoomsModel(), tea.Batch(RoomsQuery, tick)
+			}
 		}
 	}
 
+	if m.protection != nil && m.protection.Quiet() {
+		return m, nil
+	}
+
 	form, cmd := m.form.Update(msg)
 	if f, ok := form.(*huh.Form); ok {
 		m.form = f
 
 		if m.form.State == huh.StateCompleted {
 
+			if m.protection != nil {
+				roomDeleteConfirm = protectedConfirmed(m.protection, vc.ProtectDelete)
+			}
 			if roomDeleteConfirm {
 				return ReturnRoomsModel(), tea.Batch(RoomsQuery, tick, DeleteRoom(m.room.ID))
 			}
@@ -61,6 +79,14 @@ func (m DeleteRoomForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m DeleteRoomForm) View() string {
-	s := m.form.View()
-	return s
+	if m.protection == nil {
+		return m.form.View()
+	}
+
+	s := GreyedOutText.Render(fmt.Sprintf("\n🔒 delete room %s\n", m.room.ID)) + "\n"
+	s += renderProtection(m.protection, app.width) + "\n"
+	if m.protection.Quiet() {
+		return s + GreyedOutText.Render("\n\n esc return")
+	}
+	return s + "\n" + m.form.View()
 }
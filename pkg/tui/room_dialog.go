@@ -2,7 +2,6 @@ package tui
 
 import (
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/progress"
@@ -18,30 +17,49 @@ type NewRoomForm struct {
 	running  bool
 	err      error
 	edit     bool
+
+	// Changing the room ID recreates the room, the rename is confirmed before the room is deleted.
+	original *vc.Room
+	confirm  *huh.Form
+
+	// Edits are reviewed against the initial options before they are saved.
+	initial vc.RoomOptions
+	review  *huh.Form
+	changes []vc.FieldChange
 }
 
 var roomOptions *vc.RoomOptions
 var selectProg vc.ProgramEntry
 
-func validateRoomId(file string) error {
-	if strings.ContainsAny(file, " !@#$%^&*()_+{}[]|\\<,>.?/") {
-		return fmt.Errorf("ROOM ID CANNOT CONTAIN SPECIAL CHARACTERS OR SPACES %s", file)
-	}
-	return nil
+func validateRoomId(id string) error {
+	return vc.ValidateRoomId(id)
 }
 
 func validateRoomName(name string) error {
-	if len(name) < 5 {
-		return fmt.Errorf("NAME %s MUST HAVE AT LEAST 5 CHARATERS", name)
+	return vc.ValidateRoomName(name)
+}
+
+// The user file is optional, JSON and CSV files are parsed before they are uploaded.
+func validateUserFile(file string) error {
+	if len(file) == 0 {
+		return nil
 	}
-	return nil
+	return vc.ValidateUserFile(file)
 }
 
 var originalRoomId *string
+var roomRenameConfirm bool
 
+// The room ID can be changed, the new ID must not be used by another room.
 func validateEditRoomId(id string) error {
-	if id != *originalRoomId {
-		return fmt.Errorf("CANNOT EDIT THE ROOM ID, VALUE MUST BE %s", *originalRoomId)
+	if id == *originalRoomId {
+		return nil
+	}
+	if err := validateRoomId(id); err != nil {
+		return err
+	}
+	if existing, _ := roomsModel.rooms.WithIDs([]string{id}); len(existing) > 0 {
+		return fmt.Errorf("ROOM %s ALREADY EXISTS", id)
 	}
 	return nil
 }
@@ -167,6 +185,7 @@ func NewRoomFormModelWithPrograms(programs vc.Programs) NewRoomForm {
 					Title("Upload User File").
 					Prompt("👤  ").
 					Placeholder("/home/user/myconfig.json").
+					Validate(validateUserFile).
 					Value(&roomOptions.UserFile),
 			),
 		).WithTheme(huh.ThemeDracula()),
@@ -254,6 +273,7 @@ func NewRoomFromProgramFormModel(programEntry *vc.ProgramEntry) NewRoomForm {
 					Title("Upload User File").
 					Prompt("👤  ").
 					Placeholder("/home/user/myconfig.json").
+					Validate(validateUserFile).
 					Value(&roomOptions.UserFile),
 			),
 		).WithTheme(huh.ThemeDracula()),
@@ -262,7 +282,7 @@ func NewRoomFromProgramFormModel(programEntry *vc.ProgramEntry) NewRoomForm {
 
 func EditRoomFormModel(room *vc.Room) NewRoomForm {
 	originalRoomId = &room.ID
-	roomOptions = &vc.RoomOptions{
+	initial := vc.RoomOptions{
 		ProgramInstanceId:   room.ID,
 		ProgramLibraryId:    int(room.ProgramID),
 		Name:                room.Name,
@@ -271,85 +291,95 @@ func EditRoomFormModel(room *vc.Room) NewRoomForm {
 		Latitude:            room.Latitude,
 		Longitude:           room.Longitude,
 		TimeZone:            room.TimeZone,
-		AddressSetsLocation: true,
+		AddressSetsLocation: room.AddressSetsLocation,
 	}
+	roomOptions = &initial
 
 	p := progress.New(progress.WithDefaultGradient())
 	p.Width = app.width
 
 	return NewRoomForm{
 		edit:     true,
+		original: room,
+		initial:  initial,
 		running:  false,
 		progress: p,
-		form: huh.NewForm(
-			huh.NewGroup(
-
-				huh.NewInput().
-					Key("NAME").
-					Title("Enter Friendly Name").
-					Prompt("📛  ").
-					Placeholder("My friendly program name").
-					Validate(validateRoomName).
-					Value(&roomOptions.Name),
-
-				huh.NewInput().
-					Key("ROOM ID").
-					Title("Enter Room ID").
-					Prompt("🆔  ").
-					Placeholder(*originalRoomId).
-					Validate(validateEditRoomId).
-					Value(&roomOptions.ProgramInstanceId),
-
-				huh.NewInput().
-					Key("NOTES").
-					Title("Enter Notes").
-					Prompt("📝  ").
-					Placeholder("My seemingly pointless notes").
-					Value(&roomOptions.Notes),
-
-				huh.NewInput().
-					Key("ADDRESS").
-					Title("Location").
-					Prompt("🏚  ").
-					Placeholder("404 Bad Address Location").
-					Value(&roomOptions.Location),
-
-				huh.NewConfirm().
-					Title("Address Sets Location").
-					Value(&roomOptions.AddressSetsLocation),
-
-				huh.NewInput().
-					Key("TIMEZONE").
-					Title("Time Zone").
-					Prompt("⏲  ").
-					Placeholder("+/- numeric value").
-					Value(&roomOptions.TimeZone),
-
-				huh.NewInput().
-					Key("LAT").
-					Title("Latitude").
-					Prompt("🌐  ").
-					Placeholder("39.352862").
-					Value(&roomOptions.Latitude),
-
-				huh.NewInput().
-					Key("LONG").
-					Title("Longitude").
-					Prompt("🌐  ").
-					Placeholder("-76.407341").
-					Value(&roomOptions.Longitude),
-
-				huh.NewInput().
-					Key("USER_FILE").
-					Title("Upload User File").
-					Prompt("👤  ").
-					Placeholder("/home/user/myconfig.json").
-					Value(&roomOptions.UserFile),
-			),
-		).WithTheme(huh.ThemeDracula()),
+		form:     editRoomForm(),
 	}
 }
 
+// Creates the edit form using the current room options, the values are kept when returning from the review.
+func editRoomForm() *huh.Form {
+	return huh.NewForm(
+		huh.NewGroup(
+
+			huh.NewInput().
+				Key("NAME").
+				Title("Enter Friendly Name").
+				Prompt("📛  ").
+				Placeholder("My friendly program name").
+				Validate(validateRoomName).
+				Value(&roomOptions.Name),
+
+			huh.NewInput().
+				Key("ROOM ID").
+				Title("Enter Room ID").
+				Prompt("🆔  ").
+				Placeholder(*originalRoomId).
+				Description("changing the ID deletes and recreates the room").
+				Validate(validateEditRoomId).
+				Value(&roomOptions.ProgramInstanceId),
+
+			huh.NewInput().
+				Key("NOTES").
+				Title("Enter Notes").
+				Prompt("📝  ").
+				Placeholder("My seemingly pointless notes").
+				Value(&roomOptions.Notes),
+
+			huh.NewInput().
+				Key("ADDRESS").
+				Title("Location").
+				Prompt("🏚  ").
+				Placeholder("404 Bad Address Location").
+				Value(&roomOptions.Location),
+
+			huh.NewConfirm().
+				Title("Address Sets Location").
+				Value(&roomOptions.AddressSetsLocation),
+
+			huh.NewInput().
+				Key("TIMEZONE").
+				Title("Time Zone").
+				Prompt("⏲  ").
+				Placeholder("+/- numeric value").
+				Value(&roomOptions.TimeZone),
+
+			huh.NewInput().
+				Key("LAT").
+				Title("Latitude").
+				Prompt("🌐  ").
+				Placeholder("39.352862").
+				Value(&roomOptions.Latitude),
+
+			huh.NewInput().
+				Key("LONG").
+				Title("Longitude").
+				Prompt("🌐  ").
+				Placeholder("-76.407341").
+				Value(&roomOptions.Longitude),
+
+			huh.NewInput().
+				Key("USER_FILE").
+				Title("Upload User File").
+				Prompt("👤  ").
+				Placeholder("/home/user/myconfig.json").
+				Validate(validateUserFile).
+				Value(&roomOptions.UserFile),
+		),
+	).WithTheme(huh.ThemeDracula())
+}
+
 func (m NewRoomForm) Init() tea.Cmd {
 	return m.form.Init()
 }
@@ -395,23 +425,106 @@ func (m NewRoomForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	if m.confirm != nil && !m.running {
+		return m.updateRenameConfirm(msg)
+	}
+
+	if m.review != nil && !m.running {
+		return m.updateReview(msg)
+	}
+
 	form, cmd := m.form.Update(msg)
 	if f, ok := form.(*huh.Form); ok {
 		m.form = f
 
 		if m.form.State == huh.StateCompleted && !m.running {
+			if m.edit {
+				m.changes = vc.DiffFields(m.initial, *roomOptions)
+				m.review = reviewForm(m.changes)
+				return m, m.review.Init()
+			}
+
 			m.running = true
+			roomOptions.ProgramLibraryId = int(selectProg.ProgramID)
+			return m, tea.Batch(CreateRoom(*roomOptions), roomCreatedTickCmd())
+		}
+	}
+	return m, cmd
+}
 
-			if !m.edit {
-				roomOptions.ProgramLibraryId = int(selectProg.ProgramID)
-				return m, tea.Batch(CreateRoom(*roomOptions), roomCreatedTickCmd())
+// Saves the reviewed changes or returns to the edit form with the edited values.
+func (m NewRoomForm) updateReview(msg tea.Msg) (tea.Model, tea.Cmd) {
+	form, cmd := m.review.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.review = f
+
+		if m.review.State == huh.StateCompleted {
+			if !reviewSave {
+				m.review = nil
+				m.form = editRoomForm()
+				return m, m.form.Init()
+			}
+
+			if roomOptions.ProgramInstanceId != m.original.ID {
+				m.confirm = renameRoomConfirmation(m.original, roomOptions.ProgramInstanceId)
+				return m, m.confirm.Init()
 			}
+
+			if roomEditProtection(*m.original, *roomOptions) != nil {
+				return protectedRoomAction(*m.original, vc.ProtectEditRoom, EditRoom(*roomOptions), vc.ProtectEditRoom)
+			}
+
+			m.running = true
 			return m, tea.Batch(EditRoom(*roomOptions), roomCreatedTickCmd())
 		}
 	}
 	return m, cmd
 }
 
+// The warning displayed in the review when saving the room restarts it.
+func roomRestartWarning(room *vc.Room) string {
+	if roomOptions.ProgramInstanceId == room.ID {
+		return ""
+	}
+	return fmt.Sprintf("%s will be stopped, deleted, and created again as %s", room.ID, roomOptions.ProgramInstanceId)
+}
+
+func (m NewRoomForm) updateRenameConfirm(msg tea.Msg) (tea.Model, tea.Cmd) {
+	form, cmd := m.confirm.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.confirm = f
+
+		if m.confirm.State == huh.StateCompleted {
+			if !roomRenameConfirm {
+				return ReturnRoomsModel(), tea.Batch(tick, RoomsQuery)
+			}
+			m.running = true
+			return m, tea.Batch(RenameRoom(*m.original, *roomOptions), roomCreatedTickCmd())
+		}
+	}
+	return m, cmd
+}
+
+func renameRoomConfirmation(room *vc.Room, id string) *huh.Form {
+	roomRenameConfirm = false
+
+	warning := fmt.Sprintf("IP table entries are not moved, devices connected to %s must be added to %s.", room.ID, id)
+	if len(room.UserFile) > 0 && len(roomOptions.UserFile) == 0 {
+		warning += fmt.Sprintf(" The user file %s can't be copied.", room.UserFile)
+	}
+
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(fmt.Sprintf("Rename %s to %s? The room will be stopped, deleted, and created again.", room.ID, id)).
+				Description(warning).
+				Value(&roomRenameConfirm).
+				Affirmative("Rename").
+				Negative("Cancel"),
+		),
+	).WithTheme(huh.ThemeDracula())
+}
+
 func (m NewRoomForm) View() string {
 	s := ""
 	if m.edit {
@@ -420,7 +533,16 @@ func (m NewRoomForm) View() string {
 		s += GreyedOutText.Render("\n🆕 Create New Program Instance\n")
 	}
 
-	s += "\n" + m.form.View()
+	if m.confirm != nil {
+		s += "\n" + m.confirm.View()
+	} else if m.review != nil {
+		s += "\n" + renderReview(m.changes, roomRestartWarning(m.original), app.width)
+		if !m.running {
+			s += "\n" + m.review.View()
+		}
+	} else {
+		s += "\n" + m.form.View()
+	}
 
 	if m.progress.Percent() != 0.0 {
 		s += "\n" + m.progress.View() + "\n\n"
@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+var (
+	protectedConfirmTarget string
+)
+
+// Asks the operator to type the room ID before a protected room is stopped or restarted.
+type ProtectedRoomForm struct {
+	room       vc.Room
+	protection *vc.ProtectedError
+	form       *huh.Form
+	run        tea.Cmd
+	// The actions approved once the room ID is typed, a restart is sent as a stop and start.
+	approve []string
+}
+
+// Runs the room action, the room ID must be typed first when the policy protects the room.
+func protectedRoomAction(room vc.Room, action string, run tea.Cmd, approve ...string) (tea.Model, tea.Cmd) {
+	protection := vc.CheckPolicy(server, action, room.ID, room)
+	if protection == nil {
+		return roomsModel, run
+	}
+
+	m := ProtectedRoomForm{
+		room:       room,
+		protection: protection,
+		form:       protectedConfirmForm(protection),
+		run:        run,
+		approve:    approve,
+	}
+	return m, m.form.Init()
+}
+
+// Returns the protection of the room when the edit changes the program or uploads a user file.
+func roomEditProtection(room vc.Room, options vc.RoomOptions) *vc.ProtectedError {
+	if int(room.ProgramID) == options.ProgramLibraryId && len(options.UserFile) == 0 {
+		return nil
+	}
+	return vc.CheckPolicy(server, vc.ProtectEditRoom, room.ID, room)
+}
+
+// Creates the typed confirmation for a protected action.
+func protectedConfirmForm(protection *vc.ProtectedError) *huh.Form {
+	protectedConfirmTarget = ""
+	target := protection.Target
+
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title(fmt.Sprintf("%s is protected, are you sure you want to %s it?", target, protection.Action)).
+				Description(fmt.Sprintf("type %s to confirm, esc to cancel", target)).
+				Prompt("⚠  ").
+				Validate(func(s string) error {
+					if s != target {
+						return fmt.Errorf("TYPE %s TO CONFIRM", target)
+					}
+					return nil
+				}).
+				Value(&protectedConfirmTarget),
+		),
+	).WithTheme(huh.ThemeDracula())
+}
+
+// Returns true when the target was typed, the actions are approved for a single call.
+func protectedConfirmed(protection *vc.ProtectedError, actions ...string) bool {
+	if protection.Quiet() || protectedConfirmTarget != protection.Target {
+		return false
+	}
+	for _, action := range actions {
+		vc.ApprovePolicy(server, action, protection.Target)
+	}
+	return true
+}
+
+// Renders the rooms and rules protecting the action, quiet hours are rendered as an error.
+func renderProtection(protection *vc.ProtectedError, width int) string {
+	if protection.Quiet() {
+		return RenderErrorBox(fmt.Sprintf("%s %s is blocked by quiet hours", protection.Action, protection.Target), protection)
+	}
+	return RenderWarningBox(width).Render(fmt.Sprintf("%s is protected by %s", protection.Target, strings.Join(protection.Rules, ", ")))
+}
+
+func (m ProtectedRoomForm) Init() tea.Cmd {
+	return m.form.Init()
+}
+
+func (m ProtectedRoomForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "shift+tab", "ctrl+q":
+			return ReturnRoomsModel(), tea.Batch(RoomsQuery, tick)
+		}
+	}
+
+	if m.protection.Quiet() {
+		return m, nil
+	}
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+
+		if m.form.State == huh.StateCompleted {
+			if protectedConfirmed(m.protection, m.approve...) {
+				return ReturnRoomsModel(), tea.Batch(RoomsQuery, tick, m.run)
+			}
+			return ReturnRoomsModel(), tea.Batch(RoomsQuery, tick)
+		}
+	}
+	return m, cmd
+}
+
+func (m ProtectedRoomForm) View() string {
+	s := GreyedOutText.Render(fmt.Sprintf("\n🔒 %s room %s\n", m.protection.Action, m.room.ID)) + "\n"
+	s += renderProtection(m.protection, app.width) + "\n"
+
+	if m.protection.Quiet() {
+		return s + GreyedOutText.Render("\n\n esc return")
+	}
+	return s + "\n" + m.form.View()
+}
@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+type RebindRoomForm struct {
+	room    *vc.Room
+	form    *huh.Form
+	result  *vc.RoomCreatedResult
+	running bool
+	err     error
+}
+
+var rebindProgram vc.ProgramEntry
+
+// Binds the room to a different program from the library.
+// The form is created once the program library has been loaded.
+func RebindRoomFormModel(room *vc.Room) RebindRoomForm {
+	return RebindRoomForm{
+		room: room,
+	}
+}
+
+func rebindRoomForm(room *vc.Room, programs vc.Programs) *huh.Form {
+	progs := make([]huh.Option[vc.ProgramEntry], 0, len(programs))
+	for _, p := range programs {
+		progs = append(progs, huh.NewOption(fmt.Sprintf("%s (%s)", p.FriendlyName, p.AppFile), p))
+	}
+
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[vc.ProgramEntry]().
+				Title(fmt.Sprintf("Select the program room %s will run", room.ID)).
+				Options(progs...).
+				Value(&rebindProgram).
+				Description("the room will be bound to the selected program"),
+		),
+	).WithTheme(huh.ThemeDracula())
+}
+
+func (m RebindRoomForm) Init() tea.Cmd {
+	return nil
+}
+
+func (m RebindRoomForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case error:
+		m.err = msg
+		return m, nil
+
+	case vc.Programs:
+		if len(msg) == 0 {
+			m.err = fmt.Errorf("THERE ARE NO PROGRAMS LOADED TO THE SYSTEM, CREATE A PROGRAM BEFORE REBINDING ROOM %s", m.room.ID)
+			return m, nil
+		}
+		m.form = rebindRoomForm(m.room, msg)
+		return m, m.form.Init()
+
+	case vc.RoomCreatedResult:
+		m.result = &msg
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "ctrl+q":
+			return ReturnRoomsModel(), tea.Batch(tick, RoomsQuery)
+		}
+	}
+
+	if m.form == nil {
+		return m, nil
+	}
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+
+		if m.form.State == huh.StateCompleted && !m.running {
+			m.running = true
+
+			options := vc.NewRoomOptionsFromRoom(*m.room)
+			options.ProgramLibraryId = int(rebindProgram.ProgramID)
+			if roomEditProtection(*m.room, options) != nil {
+				return protectedRoomAction(*m.room, vc.ProtectEditRoom, EditRoom(options), vc.ProtectEditRoom)
+			}
+			return m, EditRoom(options)
+		}
+	}
+	return m, cmd
+}
+
+func (m RebindRoomForm) View() string {
+	s := GreyedOutText.Render(fmt.Sprintf("\n🔗 Rebind Room %s\n", m.room.ID))
+
+	if m.form == nil && m.err == nil {
+		s += "\n" + RenderMessageBox(app.width).Render("loading program library, please wait...")
+		return s
+	}
+
+	if m.form != nil {
+		s += "\n" + m.form.View()
+	}
+
+	if m.err != nil {
+		s += RenderErrorBox("error rebinding room", m.err)
+		s += GreyedOutText.Render("\n\n esc return")
+		return s
+	}
+
+	if m.result != nil {
+		var resultMessage string
+		resultMessage += "\n RESULT           " + m.result.Message
+		resultMessage += "\n\n PROGRAM:         " + rebindProgram.FriendlyName
+		resultMessage += "\n\n STATUS CODE:     " + fmt.Sprintf("%d", m.result.Code)
+		resultMessage += "\n"
+
+		s += RenderMessageBox(app.width).Render(resultMessage)
+		s += GreyedOutText.Render("\n\n esc return")
+	}
+
+	return s
+}
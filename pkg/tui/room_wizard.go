@@ -0,0 +1,280 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/ewilliams0305/VC4-CLI/pkg/vc"
+)
+
+// The number of rooms the wizard creates at the same time.
+const wizardParallel = 4
+
+type RoomWizard struct {
+	programs vc.Programs
+	rooms    vc.Rooms
+	form     *huh.Form
+	confirm  *huh.Form
+	options  []vc.RoomOptions
+	problems []error
+	results  []vc.CreateRoomResult
+	running  bool
+	err      error
+}
+
+var (
+	wizardProgram  vc.ProgramEntry
+	wizardId       string
+	wizardName     string
+	wizardDefaults vc.RoomOptions
+	wizardConfirm  bool
+)
+
+// Creates many rooms from an ID pattern and a name template.
+// The form is created once the programs and rooms have been loaded.
+func RoomWizardModel() RoomWizard {
+	wizardProgram = vc.ProgramEntry{}
+	wizardId = ""
+	wizardName = "{id}"
+	wizardDefaults = vc.RoomOptions{AddressSetsLocation: true}
+	wizardConfirm = false
+
+	return RoomWizard{}
+}
+
+func validateIdPattern(pattern string) error {
+	ids, err := vc.ExpandPattern(pattern)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := validateRoomId(id.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func roomWizardForm(programs vc.Programs) *huh.Form {
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[vc.ProgramEntry]().
+				Title("Select a program").
+				Options(huh.NewOptions[vc.ProgramEntry](programs...)...).
+				Value(&wizardProgram).
+				Description("every room will run the selected program"),
+
+			huh.NewInput().
+				Title("Enter the room ID pattern").
+				Description("ranges CONF{01..20} and lists ROOM{A,B,C} are expanded").
+				Prompt("🆔  ").
+				Placeholder("CONF{01..20}").
+				Validate(validateIdPattern).
+				Value(&wizardId),
+
+			huh.NewInput().
+				Title("Enter the room name template").
+				Description("{id} is replaced with the room ID, {n} with the values from the ID pattern").
+				Prompt("📛  ").
+				Placeholder("Conference {n}").
+				Value(&wizardName),
+
+			huh.NewInput().
+				Title("Location").
+				Prompt("🏚  ").
+				Placeholder("404 Bad Address Location").
+				Value(&wizardDefaults.Location),
+
+			huh.NewInput().
+				Title("Time Zone").
+				Prompt("⏲  ").
+				Placeholder("+/- numeric value").
+				Value(&wizardDefaults.TimeZone),
+
+			huh.NewInput().
+				Title("Enter Notes").
+				Prompt("📝  ").
+				Placeholder("My seemingly pointless notes").
+				Value(&wizardDefaults.Notes),
+		),
+	).WithTheme(huh.ThemeDracula())
+}
+
+func (m RoomWizard) Init() tea.Cmd {
+	return tea.Batch(ProgramsQuery, RoomsQuery)
+}
+
+func (m RoomWizard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case error:
+		m.err = msg
+		return m, nil
+
+	case vc.Programs:
+		m.programs = msg
+		return m.createForm()
+
+	case vc.Rooms:
+		m.rooms = msg
+		return m.createForm()
+
+	case []vc.CreateRoomResult:
+		m.results = msg
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "ctrl+q":
+			if !m.running || m.results != nil {
+				return ReturnRoomsModel(), tea.Batch(tick, RoomsQuery)
+			}
+		case "ctrl+n":
+			if !m.running || m.results != nil {
+				wizard := RoomWizardModel()
+				return wizard, wizard.Init()
+			}
+		}
+	}
+
+	if m.confirm != nil {
+		if m.running {
+			return m, nil
+		}
+		form, cmd := m.confirm.Update(msg)
+		if f, ok := form.(*huh.Form); ok {
+			m.confirm = f
+
+			if m.confirm.State == huh.StateCompleted {
+				if !wizardConfirm {
+					return ReturnRoomsModel(), tea.Batch(tick, RoomsQuery)
+				}
+				m.running = true
+				return m, CreateRooms(m.options)
+			}
+		}
+		return m, cmd
+	}
+
+	if m.form == nil || m.problems != nil {
+		return m, nil
+	}
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+
+		if m.form.State == huh.StateCompleted {
+			wizardDefaults.ProgramLibraryId = int(wizardProgram.ProgramID)
+
+			options, err := vc.ExpandRoomTemplate(wizardId, wizardName, wizardDefaults)
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.options = options
+			m.problems = vc.ValidateNewRooms(m.rooms, options)
+			if len(m.problems) > 0 {
+				return m, nil
+			}
+
+			m.confirm = huh.NewForm(
+				huh.NewGroup(
+					huh.NewConfirm().
+						Title(fmt.Sprintf("Create %d rooms running %s?", len(options), wizardProgram.FriendlyName)).
+						Value(&wizardConfirm).
+						Affirmative("Yes").
+						Negative("Cancel"),
+				),
+			).WithTheme(huh.ThemeDracula())
+			return m, m.confirm.Init()
+		}
+	}
+	return m, cmd
+}
+
+// Creates the form once both the programs and the rooms have been loaded.
+func (m RoomWizard) createForm() (tea.Model, tea.Cmd) {
+	if m.programs == nil || m.rooms == nil || m.form != nil {
+		return m, nil
+	}
+	if len(m.programs) == 0 {
+		m.err = fmt.Errorf("THERE ARE NO PROGRAMS LOADED TO THE SYSTEM, CREATE A PROGRAM BEFORE CREATING ROOMS")
+		return m, nil
+	}
+	m.form = roomWizardForm(m.programs)
+	return m, m.form.Init()
+}
+
+func (m RoomWizard) View() string {
+	s := GreyedOutText.Render("\n🏢 Create Many Rooms\n")
+
+	if m.err != nil {
+		s += RenderErrorBox("error creating rooms", m.err)
+		s += GreyedOutText.Render("\n\n esc return * ctrl+n reset form")
+		return s
+	}
+
+	if m.form == nil {
+		s += "\n" + RenderMessageBox(app.width).Render("loading rooms and programs, please wait...")
+		return s
+	}
+
+	if m.options == nil {
+		s += "\n" + m.form.View()
+		return s
+	}
+
+	s += "\n" + RenderMessageBox(app.width).Render(renderRoomOptions(m.options, m.results))
+
+	if len(m.problems) > 0 {
+		problems := make([]string, 0, len(m.problems))
+		for _, p := range m.problems {
+			problems = append(problems, p.Error())
+		}
+		s += RenderErrorBox(fmt.Sprintf("found %d problems, no rooms were created", len(m.problems)), fmt.Errorf("%s", strings.Join(problems, "\n")))
+		s += GreyedOutText.Render("\n\n esc return * ctrl+n reset form")
+		return s
+	}
+
+	if m.running && m.results == nil {
+		s += RenderMessageBox(app.width).Render(fmt.Sprintf("creating %d rooms, please wait...", len(m.options)))
+		return s
+	}
+
+	if m.results != nil {
+		s += GreyedOutText.Render("\n esc return * ctrl+n reset form")
+		return s
+	}
+
+	s += "\n" + m.confirm.View()
+	return s
+}
+
+// Renders the rooms that will be created, once created the result of each room is included.
+func renderRoomOptions(options []vc.RoomOptions, results []vc.CreateRoomResult) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "ROOM\tNAME\tLOCATION\tTIME ZONE\tRESULT")
+	for i, o := range options {
+		result := ""
+		if i < len(results) {
+			result = "✅ " + results[i].Result.Message
+			if results[i].Err != nil {
+				result = "❌ " + results[i].Err.Error()
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", o.ProgramInstanceId, o.Name, o.Location, o.TimeZone, result)
+	}
+	w.Flush()
+	return b.String()
+}
+
+func CreateRooms(options []vc.RoomOptions) tea.Cmd {
+	return func() tea.Msg {
+		return vc.CreateRooms(server, options, wizardParallel, nil)
+	}
+}
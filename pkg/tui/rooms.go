@@ -23,6 +23,7 @@ type RoomsTableModel struct {
 	cursor        int
 	width, height int
 	banner        *BannerModel
+	yank          yanker
 }
 
 func InitialRoomsModel(width, height int) *RoomsTableModel {
@@ -106,6 +107,12 @@ func (m RoomsTableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return NewRoomsErrorTable(msg), nil
 
 	case tea.KeyMsg:
+		if roomsModel.yank.handle(msg, getRoomsColumns(roomsModel.width), roomsModel.table.SelectedRow(), roomsModel.selectedRoom) {
+			return roomsModel, nil
+		}
+		if readOnlyKey(msg, roomsMutatingKeys) {
+			return roomsModel, nil
+		}
 		switch msg.String() {
 
 		case "ctrl+q", "q", "ctrl+c", "esc":
@@ -129,9 +136,9 @@ func (m RoomsTableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "ctrl+s":
 			if roomsModel.err == nil {
 				if m.selectedRoom.Status == string(vc.Running) {
-					return roomsModel, cmdRoomStop(roomsModel.selectedRoom.ID)
+					return protectedRoomAction(roomsModel.selectedRoom, vc.ProtectStop, cmdRoomStop(roomsModel.selectedRoom.ID), vc.ProtectStop)
 				} else if roomsModel.selectedRoom.Status == string(vc.Starting) {
-					return roomsModel, cmdRoomStop(roomsModel.selectedRoom.ID)
+					return protectedRoomAction(roomsModel.selectedRoom, vc.ProtectStop, cmdRoomStop(roomsModel.selectedRoom.ID), vc.ProtectStop)
 				} else if roomsModel.selectedRoom.Status == string(vc.Stopped) {
 					return roomsModel, cmdRoomStart(roomsModel.selectedRoom.ID)
 				} else if roomsModel.selectedRoom.Status == string(vc.Stopping) {
@@ -143,7 +150,8 @@ func (m RoomsTableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "ctrl+r":
 			if roomsModel.err == nil {
-				return roomsModel, cmdRoomRestart(roomsModel.selectedRoom.ID)
+				// The restart is sent as a stop and start, confirming the restart approves the stop.
+				return protectedRoomAction(roomsModel.selectedRoom, vc.ProtectRestart, cmdRoomRestart(roomsModel.selectedRoom.ID), vc.ProtectStop)
 			}
 
 		case "ctrl+d":
@@ -157,12 +165,34 @@ func (m RoomsTableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return form, form.Init()
 			}
 
+		case "e":
+			if roomsModel.err == nil && len(roomsModel.selectedRoom.ID) > 0 && !roomsModel.selectedRoom.Orphaned {
+				editor := RoomEditorModel(&roomsModel.selectedRoom)
+				return editor, editor.Init()
+			}
+
 		case "delete":
 			if roomsModel.err == nil {
 				form := DeleteRoomFormModel(&roomsModel.selectedRoom)
 				return form, form.Init()
 			}
 
+		case "ctrl+b":
+			if roomsModel.err == nil && len(roomsModel.selectedRoom.ID) > 0 {
+				form := RebindRoomFormModel(&roomsModel.selectedRoom)
+				return form, tea.Batch(ProgramsQuery, form.Init())
+			}
+
+		case "ctrl+o":
+			if roomsModel.err == nil && len(roomsModel.selectedRoom.ID) > 0 && !roomsModel.selectedRoom.Orphaned {
+				form := CloneRoomFormModel(&roomsModel.selectedRoom, roomsModel.rooms)
+				return form, form.Init()
+			}
+
+		case "ctrl+g":
+			wizard := RoomWizardModel()
+			return wizard, wizard.Init()
+
 		case "ctrl+n":
 			form := NewRoomFormModel()
 			return form, tea.Batch(ProgramsQuery, form.Init())
@@ -178,11 +208,18 @@ func (m RoomsTableModel) View() string {
 
 	if m.busy.flag {
 		s += RenderMessageBox(m.width).Render(m.busy.message)
+	} else if m.selectedRoom.Orphaned {
+		room := fmt.Sprintf("%s room %s is bound to program %d which no longer exists, press ctrl+b to rebind or delete to remove the room\n", OrphanedMarker, m.selectedRoom.ID, m.selectedRoom.ProgramID)
+		s += RenderWarningBox(m.width).Render(room)
+	} else if ReadOnly {
+		room := fmt.Sprintf("\u2192 read only, %s %s can be viewed but not changed (ctrl+t ip table)\n", m.selectedRoom.ID, m.selectedRoom.ProgramName)
+		s += RenderMessageBox(m.width).Render(room)
 	} else {
 		room := fmt.Sprintf("\u2192 use keyboard actions to manage %s %s (ctrl+s, ctrl+d...)\n", m.selectedRoom.ID, m.selectedRoom.ProgramName)
 		s += RenderMessageBox(m.width).Render(room)
 	}
 
+	s += m.yank.View(getRoomsColumns(m.width))
 	s += m.help.renderHelpInfo()
 	return s
 }
@@ -216,6 +253,9 @@ func newRoomsTable(rooms vc.Rooms, cursor int, width int) table.Model {
 	return t
 }
 
+// The narrowest terminal displaying the user file column, narrower terminals keep the width for the notes.
+const userFileColumnWidth = 170
+
 func getRoomsColumns(width int) []table.Column {
 
 	if width < 120 {
@@ -228,12 +268,26 @@ func getRoomsColumns(width int) []table.Column {
 			{Title: "DEBUG", Width: 8},
 		}
 	}
+	if width < userFileColumnWidth {
+
+		return []table.Column{
+			{Title: "", Width: 1},
+			{Title: "ID", Width: 20},
+			{Title: "NAME", Width: 30},
+			{Title: "PROGRAM", Width: 30},
+			{Title: "NOTES", Width: width - 141},
+			{Title: "TYPE", Width: 16},
+			{Title: "STATUS", Width: 8},
+			{Title: "DEBUG", Width: 8},
+		}
+	}
 	return []table.Column{
 		{Title: "", Width: 1},
 		{Title: "ID", Width: 20},
-		{Title: "NAME", Width: 35},
-		{Title: "PROGRAM", Width: 35},
-		{Title: "NOTES", Width: width - 141},
+		{Title: "NAME", Width: 30},
+		{Title: "PROGRAM", Width: 30},
+		{Title: "NOTES", Width: width - 151},
+		{Title: "USER FILE", Width: 20},
 		{Title: "TYPE", Width: 16},
 		{Title: "STATUS", Width: 8},
 		{Title: "DEBUG", Width: 8},
@@ -249,10 +303,18 @@ func getRoomsRows(width int, cursor int, rooms vc.Rooms) []table.Row {
 		if cursor == i {
 			marker = "\u2192"
 		}
+		id := room.ID
+		program := room.ProgramName
+		if room.Orphaned {
+			id = OrphanedMarker + " " + room.ID
+			program = fmt.Sprintf("MISSING PROGRAM %d", room.ProgramID)
+		}
 		if small {
-			rows = append(rows, table.Row{marker, room.ID, room.Name, GetStatus(room.Status), CheckMark(room.Debugging)})
+			rows = append(rows, table.Row{marker, id, room.Name, GetStatus(room.Status), CheckMark(room.Debugging)})
+		} else if width < userFileColumnWidth {
+			rows = append(rows, table.Row{marker, id, room.Name, program, room.Notes, room.ProgramType, GetStatus(room.Status), CheckMark(room.Debugging)})
 		} else {
-			rows = append(rows, table.Row{marker, room.ID, room.Name, room.ProgramName, room.Notes, room.ProgramType, GetStatus(room.Status), CheckMark(room.Debugging)})
+			rows = append(rows, table.Row{marker, id, room.Name, program, room.Notes, room.UserFile, room.ProgramType, GetStatus(room.Status), CheckMark(room.Debugging)})
 		}
 	}
 	return rows
@@ -399,6 +461,23 @@ func EditRoom(options vc.RoomOptions) tea.Cmd {
 	}
 }
 
+// Changes the room ID, the original configuration is saved to the backup directory.
+func RenameRoom(room vc.Room, options vc.RoomOptions) tea.Cmd {
+	return func() tea.Msg {
+		result, err := vc.RenameRoom(server, room, options, vc.DefaultBackupDir())
+		if err != nil {
+			if len(result.Backup) > 0 {
+				return fmt.Errorf("%w\n\nBACKUP SAVED TO %s", err, result.Backup)
+			}
+			return err
+		}
+		return vc.RoomCreatedResult{
+			Message: fmt.Sprintf("Room renamed to %s, backup saved to %s", options.ProgramInstanceId, result.Backup),
+			Success: true,
+		}
+	}
+}
+
 func DeleteRoom(id string) tea.Cmd {
 
 	return func() tea.Msg {
@@ -24,20 +24,25 @@ type roomsKeyMap struct {
 	Delete  key.Binding
 	Edit    key.Binding
 	Table   key.Binding
+	Rebind  key.Binding
+	Clone   key.Binding
+	Wizard  key.Binding
+	Yaml    key.Binding
+	Yank    key.Binding
 }
 
 // ShortHelp returns keybindings to be shown in the mini help view. It's part
 // of the key.Map interface.
 func (k roomsKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Quit, k.Up, k.Down, k.Start, k.Restart, k.Debug, k.Delete, k.Table, k.Create, k.Edit}
+	return []key.Binding{k.Quit, k.Up, k.Down, k.Start, k.Restart, k.Debug, k.Delete, k.Table, k.Create, k.Edit, k.Yank}
 }
 
 // FullHelp returns keybindings for the expanded help view. It's part of the
 // key.Map interface.
 func (k roomsKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Help, k.Up, k.Down},      // first column
-		{k.Start, k.Stop, k.Delete}, // second column
+		{k.Help, k.Up, k.Down}, // first column
+		{k.Start, k.Stop, k.Delete, k.Rebind, k.Clone, k.Wizard, k.Yaml}, // second column
 	}
 }
 
@@ -90,6 +95,26 @@ var roomKeys = roomsKeyMap{
 		key.WithKeys("delete"),
 		key.WithHelp("delete", "delete room"),
 	),
+	Rebind: key.NewBinding(
+		key.WithKeys("ctrl+b"),
+		key.WithHelp("ctrl+b", "rebind program"),
+	),
+	Clone: key.NewBinding(
+		key.WithKeys("ctrl+o"),
+		key.WithHelp("ctrl+o", "clone room"),
+	),
+	Wizard: key.NewBinding(
+		key.WithKeys("ctrl+g"),
+		key.WithHelp("ctrl+g", "create many rooms"),
+	),
+	Yaml: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "edit in $EDITOR"),
+	),
+	Yank: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "copy"),
+	),
 }
 
 type RoomsHelpModel struct {
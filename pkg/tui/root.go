@@ -3,6 +3,8 @@ package tui
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -155,6 +157,8 @@ func (m MainModel) View() string {
 	}
 
 	s = DisplayLogo(m.width)
+	s += renderReadOnly(m.width)
+	s += renderDryRun(m.width)
 
 	if m.err != nil {
 		info := NewDeviceErrorTable(m.err, m.width)
@@ -212,7 +216,14 @@ func arrowSelected(m *MainModel) (tea.Model, tea.Cmd) {
 
 func Run() {
 
-	server = initServer()
+	var err error
+	server, err = NewServer()
+	if err != nil {
+		fmt.Printf("VC4 CLI failed to connect, there's been an error: %v", err)
+		os.Exit(1)
+	}
+	DetectReadOnly(server)
+
 	initialView, err := initActions()
 	if err != nil {
 		fmt.Printf("VC4 CLI failed execute intial actions, there's been an error: %v", err)
@@ -220,18 +231,107 @@ func Run() {
 	}
 
 	p := tea.NewProgram(initialView, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	_, err = p.Run()
+	FlushAudit()
+	if err != nil {
 		fmt.Printf("VC4 CLI failed to start, there's been an error: %v", err)
 		os.Exit(1)
 	}
 }
 
-func initServer() vc.VirtualControl {
+// Creates the VC client described by the application flags.
+// The client is shared by the TUI and the vcli sub commands.
+func NewServer() (vc.VirtualControl, error) {
+	if err := loadProfile(); err != nil {
+		return nil, err
+	}
+
+	opts, err := serverOptions(ProfileName)
+	if err != nil {
+		return nil, err
+	}
+	if len(ActiveProfile.CertFingerprint) > 0 {
+		opts = append(opts, vc.WithPinnedCert(ActiveProfile.CertFingerprint))
+	}
+
 	// TODO: Possible create an error if token and host are invlid
+	return protectServer(newVC(append(opts, vc.WithReadOnly(readOnlyMode))...))
+}
+
+func newVC(opts ...vc.VcOptsFunc) vc.VirtualControl {
 	if (len(Hostname) > 0) && (len(Token) > 0) {
-		return vc.NewRemoteVC(Hostname, Token)
+		return vc.NewRemoteVC(Hostname, Token, opts...)
+	}
+	return vc.NewLocalVC(opts...)
+}
+
+func serverOptions(profile string) ([]vc.VcOptsFunc, error) {
+	opts := make([]vc.VcOptsFunc, 0)
+
+	if RetryTimeout > 0 {
+		policy, err := retryPolicy()
+		if err != nil {
+			return opts, err
+		}
+		opts = append(opts, vc.WithRetry(policy))
+	}
+
+	if len(ReplayFile) > 0 {
+		cassette, err := vc.LoadCassette(ReplayFile)
+		if err != nil {
+			return opts, err
+		}
+		opts = append(opts, vc.WithReplay(cassette))
+	}
+
+	if len(RecordFile) > 0 {
+		opts = append(opts, vc.WithRecorder(RecordFile))
+	}
+
+	if CacheTTL > 0 {
+		opts = append(opts, vc.WithCache(CacheTTL))
+	}
+
+	policy, err := SignaturePolicy()
+	if err != nil {
+		return opts, err
+	}
+	if len(policy.Keys) > 0 || policy.Require {
+		opts = append(opts, vc.WithSignatures(policy))
+	}
+
+	if Provenance {
+		opts = append(opts, vc.WithProvenance(Version))
+	}
+
+	audit, err := openAuditLog(profile)
+	if err != nil {
+		return opts, err
+	}
+	opts = append(opts, vc.WithAudit(audit))
+
+	if DryRun {
+		opts = append(opts, vc.WithDryRun(captureDryRun))
+	}
+	return opts, nil
+}
+
+func retryPolicy() (vc.RetryPolicy, error) {
+	policy := vc.DefaultRetryPolicy()
+	policy.MaxElapsed = RetryTimeout
+	policy.RetryableStatus = make([]int, 0)
+
+	for _, c := range strings.Split(RetryCodes, ",") {
+		if len(strings.TrimSpace(c)) == 0 {
+			continue
+		}
+		code, err := strconv.Atoi(strings.TrimSpace(c))
+		if err != nil {
+			return policy, fmt.Errorf("INVALID RETRY CODE %s", c)
+		}
+		policy.RetryableStatus = append(policy.RetryableStatus, code)
 	}
-	return vc.NewLocalVC()
+	return policy, nil
 }
 
 func initActions() (tea.Model, error) {
@@ -64,6 +64,10 @@ func (m VirtualControlServiceModel) Init() tea.Cmd {
 func (m VirtualControlServiceModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// The first three actions of the list stop, start, and restart the service.
+		if m.list.FilterState() != list.Filtering && (readOnlyKey(msg, serviceMutatingKeys) || (ReadOnly && msg.String() == "enter" && m.list.Cursor() < 3)) {
+			return m, nil
+		}
 		switch msg.String() {
 
 		case "s", "ctrl+s":
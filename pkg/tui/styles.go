@@ -14,6 +14,12 @@ const (
 	PrimaryDark  string = "#001F5F"
 	AccentColor  string = "#00796B"
 	ErrorColor   string = "#8A0B29"
+	WarningColor string = "#7A5C00"
+
+	// Displayed next to rooms bound to a program that no longer exists.
+	OrphanedMarker string = "⚠"
+	// Displayed next to programs that are not used by any room.
+	UnusedMarker string = "💤"
 )
 
 var BaseStyle = lipgloss.NewStyle().
@@ -51,6 +57,12 @@ func RenderMessageBox(width int) lipgloss.Style {
 		Height(3)
 }
 
+func RenderWarningBox(width int) lipgloss.Style {
+	return RenderMessageBox(width).
+		Foreground(lipgloss.Color("#FFFFFF")).
+		Background(lipgloss.Color(WarningColor))
+}
+
 func RenderErrorBox(header string, err error) string {
 	w, _, _ := term.GetSize(int(os.Stdout.Fd()))
 
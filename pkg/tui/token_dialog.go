@@ -17,6 +17,8 @@ type NewTokenForm struct {
 	running  bool
 	err      error
 	edit     bool
+	// The result of copying the token to the clipboard.
+	copied string
 }
 
 var tokenFormDescription string
@@ -120,6 +122,15 @@ func (m NewTokenForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "ctrl+n":
 			form := NewTokenFormModel()
 			return form, tea.Batch(form.Init(), nil)
+
+		case "y":
+			if m.result != nil {
+				m.copied = "📋 copied the token to the clipboard"
+				if err := copyToClipboard(m.result.Token); err != nil {
+					m.copied = fmt.Sprintf("❌ failed copying the token: %v", err)
+				}
+				return m, nil
+			}
 		}
 	}
 
@@ -161,7 +172,10 @@ func (m NewTokenForm) View() string {
 	if m.result != nil {
 
 		s += RenderMessageBox(app.width).Render(fmt.Sprintf("API TOKEN: %s\n\n", m.result.Token))
-		s += GreyedOutText.Render("\n\n esc return * ctrl+n reset form")
+		if len(m.copied) > 0 {
+			s += GreyedOutText.Render("\n " + m.copied)
+		}
+		s += GreyedOutText.Render("\n\n esc return * y copy token * ctrl+n reset form")
 	}
 
 	return s
@@ -20,6 +20,7 @@ type TokenModel struct {
 	help          TokensHelpModel
 	width, height int
 	banner        *BannerModel
+	yank          yanker
 }
 
 func InitialTokensModel(width, height int) *TokenModel {
@@ -82,6 +83,12 @@ func (m TokenModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.yank.handle(msg, getApiKeyColumns(m.width), m.table.SelectedRow(), m.selected) {
+			return m, nil
+		}
+		if readOnlyKey(msg, tokensMutatingKeys) {
+			return m, nil
+		}
 		switch msg.String() {
 
 		case "up":
@@ -123,6 +130,7 @@ func (m TokenModel) View() string {
 		s += RenderErrorBox("FAILED MANAGING API TOKENS", m.err)
 	}
 
+	s += m.yank.View(getApiKeyColumns(m.width))
 	s += m.help.renderHelpInfo()
 	return s
 }
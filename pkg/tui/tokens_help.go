@@ -18,12 +18,13 @@ type tokensKeyMap struct {
 	Create key.Binding
 	Delete key.Binding
 	Edit   key.Binding
+	Yank   key.Binding
 }
 
 // ShortHelp returns keybindings to be shown in the mini help view. It's part
 // of the key.Map interface.
 func (k tokensKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Quit, k.Up, k.Down, k.Create, k.Edit, k.Delete}
+	return []key.Binding{k.Quit, k.Up, k.Down, k.Create, k.Edit, k.Delete, k.Yank}
 }
 
 // FullHelp returns keybindings for the expanded help view. It's part of the
@@ -61,6 +62,10 @@ var tokenKeys = tokensKeyMap{
 		key.WithKeys("delete"),
 		key.WithHelp("delete", "delete room"),
 	),
+	Yank: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "copy"),
+	),
 }
 
 type TokensHelpModel struct {
@@ -0,0 +1,135 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Copies the text to the clipboard of the local terminal with OSC 52, the terminal may be on the other side of an SSH session.
+// The sequence is wrapped for tmux and screen so it reaches the outer terminal.
+func copyToClipboard(text string) error {
+	seq := osc52.New(text)
+	if len(os.Getenv("TMUX")) > 0 {
+		seq = seq.Tmux()
+	} else if strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		seq = seq.Screen()
+	}
+	_, err := seq.WriteTo(os.Stderr)
+	return err
+}
+
+// The yank key of the tables, y waits for the part of the selected row to copy.
+// A second y copies the row, j copies the selected object as JSON, and 1-9 copy a cell.
+type yanker struct {
+	pending bool
+	// The result of the last copy, cleared by the next key.
+	status string
+}
+
+// Handles the key pressed in a table, returns true when the key was used by the yank key.
+// The columns of width 1 hold the cursor marker and are left out of the row and cells.
+func (y *yanker) handle(msg tea.KeyMsg, columns []table.Column, row table.Row, object any) bool {
+	// Keys typed quickly arrive as a single message, "yj".
+	if msg.Type == tea.KeyRunes && len(msg.Runes) > 1 && (y.pending || msg.Runes[0] == 'y') {
+		for _, r := range msg.Runes {
+			y.handle(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}, columns, row, object)
+		}
+		return true
+	}
+
+	y.status = ""
+	if !y.pending {
+		if msg.String() != "y" {
+			return false
+		}
+		y.pending = true
+		return true
+	}
+	y.pending = false
+
+	key := msg.String()
+	if !strings.Contains("yj123456789", key) || len(key) != 1 {
+		return true
+	}
+	cells, titles := yankCells(columns, row)
+	if len(cells) == 0 {
+		y.status = "❌ no row is selected"
+		return true
+	}
+
+	var text, copied string
+	switch key {
+	case "y":
+		text, copied = strings.Join(cells, "\t"), "row"
+	case "j":
+		data, err := json.MarshalIndent(object, "", "  ")
+		if err != nil {
+			y.status = fmt.Sprintf("❌ failed copying JSON: %v", err)
+			return true
+		}
+		text, copied = string(data), "JSON"
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		i, _ := strconv.Atoi(key)
+		if i > len(cells) {
+			y.status = fmt.Sprintf("❌ the table has %d columns", len(cells))
+			return true
+		}
+		text, copied = cells[i-1], titles[i-1]
+	}
+
+	if err := copyToClipboard(text); err != nil {
+		y.status = fmt.Sprintf("❌ failed copying %s: %v", copied, err)
+		return true
+	}
+	y.status = fmt.Sprintf("📋 copied %s to the clipboard, %s", copied, yankPreview(text))
+	return true
+}
+
+func yankCells(columns []table.Column, row table.Row) (cells []string, titles []string) {
+	for i, c := range columns {
+		if c.Width <= 1 || i >= len(row) {
+			continue
+		}
+		title := c.Title
+		if len(title) == 0 {
+			title = "value"
+		}
+		cells = append(cells, strings.TrimSpace(row[i]))
+		titles = append(titles, strings.ToLower(title))
+	}
+	return cells, titles
+}
+
+func yankPreview(text string) string {
+	preview := []rune(strings.Join(strings.Fields(text), " "))
+	if len(preview) > 60 {
+		return string(preview[:57]) + "..."
+	}
+	return string(preview)
+}
+
+// Renders the choices while waiting for the part to copy and the result of the last copy.
+func (y yanker) View(columns []table.Column) string {
+	if y.pending {
+		_, titles := yankCells(columns, make(table.Row, len(columns)))
+		choices := []string{"y row", "j json"}
+		for i, t := range titles {
+			if i == 9 {
+				break
+			}
+			choices = append(choices, fmt.Sprintf("%d %s", i+1, t))
+		}
+		return GreyedOutText.Render("\n copy: "+strings.Join(choices, " • ")) + "\n"
+	}
+	if len(y.status) > 0 {
+		return GreyedOutText.Render("\n "+y.status) + "\n"
+	}
+	return ""
+}
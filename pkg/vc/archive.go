@@ -0,0 +1,265 @@
+package vc
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Files excluded from packaged directories when no exclude patterns are provided, hidden files and folders such as .git.
+var DefaultArchiveExclude = []string{".*"}
+
+// The archive created for each ancillary program file when a directory is provided.
+type ArchiveFormat struct {
+	// The short name used by the command line, cws, xpanel...
+	Name string
+	// The ProgramOptions field, CwsFile, WebxPanelFile...
+	Field     string
+	Extension string
+	// The file VC4 loads from the root of the archive, empty when the layout isn't checked.
+	Entry string
+}
+
+var archiveFormats = []ArchiveFormat{
+	{Name: "mobility", Field: "MobilityFile", Extension: ".zip"},
+	{Name: "xpanel", Field: "WebxPanelFile", Extension: ".ch5z", Entry: "index.html"},
+	{Name: "project", Field: "ProjectFile", Extension: ".ch5z", Entry: "index.html"},
+	{Name: "cws", Field: "CwsFile", Extension: ".zip", Entry: "index.html"},
+}
+
+// Returns the archive format by name or ProgramOptions field, cws or CwsFile, xpanel, project, and mobility.
+func NewArchiveFormat(name string) (ArchiveFormat, error) {
+	for _, f := range archiveFormats {
+		if strings.EqualFold(f.Name, name) || strings.EqualFold(f.Field, name) {
+			return f, nil
+		}
+	}
+	return ArchiveFormat{}, fmt.Errorf("%s IS NOT AN ANCILLARY PROGRAM FILE, USE cws, xpanel, project, OR mobility", name)
+}
+
+// Include and exclude patterns used when a directory is packaged.
+// Patterns containing a / are matched against the path relative to the directory, "assets/*.png",
+// other patterns are matched against the name of each file and folder, "*.map".
+type ArchivePatterns struct {
+	Include []string
+	Exclude []string
+}
+
+// Returns true when the file at the slash separated path relative to the packaged directory is added to the archive.
+func (p ArchivePatterns) included(rel string) bool {
+	if p.excluded(rel) {
+		return false
+	}
+	return len(p.Include) == 0 || matchesAny(p.Include, rel)
+}
+
+func (p ArchivePatterns) excluded(rel string) bool {
+	if p.Exclude == nil {
+		return matchesAny(DefaultArchiveExclude, rel)
+	}
+	return matchesAny(p.Exclude, rel)
+}
+
+func matchesAny(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		if strings.Contains(pattern, "/") {
+			// "assets/*" also matches every file below the matching folders.
+			parts := strings.Split(rel, "/")
+			for i := range parts {
+				if ok, _ := path.Match(pattern, strings.Join(parts[:i+1], "/")); ok {
+					return true
+				}
+			}
+			continue
+		}
+		for _, name := range strings.Split(rel, "/") {
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Packages the directory into an archive in a new temporary folder, the archive is named after the directory, dist.ch5z.
+// The layout is checked before the archive is written, remove the folder with the returned cleanup when the upload is complete.
+func PackageDirectory(dir string, format ArchiveFormat, patterns ArchivePatterns) (archive string, cleanup func(), err error) {
+	files, err := archiveFiles(dir, patterns)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := format.checkLayout(dir, files); err != nil {
+		return "", nil, err
+	}
+
+	temp, err := os.MkdirTemp("", "vcli-archive-*")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup = func() { os.RemoveAll(temp) }
+
+	name := filepath.Base(filepath.Clean(dir))
+	if name == "." || name == string(filepath.Separator) {
+		name = strings.TrimSuffix(format.Field, "File")
+	}
+	archive = filepath.Join(temp, name+format.Extension)
+
+	if err := writeArchive(archive, dir, files); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return archive, cleanup, nil
+}
+
+// Returns the slash separated paths of the files in the directory matching the patterns.
+func archiveFiles(dir string, patterns ArchivePatterns) ([]string, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s IS NOT A DIRECTORY", dir)
+	}
+
+	files := make([]string, 0)
+	err = filepath.WalkDir(dir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if file == dir {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			// Excluded folders are skipped, included folders are decided by the files they contain.
+			if patterns.excluded(rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.Type().IsRegular() && patterns.included(rel) {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// Checks the entry file is at the root of the archive, the most common mistake is packaging the folder containing dist.
+func (f ArchiveFormat) checkLayout(dir string, files []string) error {
+	if len(files) == 0 {
+		return fmt.Errorf("NO FILES IN %s MATCH THE INCLUDE AND EXCLUDE PATTERNS", dir)
+	}
+	if len(f.Entry) == 0 {
+		return nil
+	}
+
+	for _, file := range files {
+		if file == f.Entry {
+			return nil
+		}
+	}
+
+	for _, file := range files {
+		if path.Base(file) == f.Entry {
+			return fmt.Errorf("%s EXPECTS %s AT THE ROOT OF THE ARCHIVE, FOUND %s, PACKAGE THE %s DIRECTORY INSTEAD", f.Field, f.Entry, file, filepath.Join(dir, filepath.FromSlash(path.Dir(file))))
+		}
+	}
+	return fmt.Errorf("%s EXPECTS %s AT THE ROOT OF THE ARCHIVE, NO FILE PACKAGED FROM %s IS NAMED %s", f.Field, f.Entry, dir, f.Entry)
+}
+
+func writeArchive(archive string, dir string, files []string) error {
+	out, err := os.Create(archive)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	writer := zip.NewWriter(out)
+	for _, file := range files {
+		if err := addArchiveFile(writer, dir, file); err != nil {
+			return err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+func addArchiveFile(writer *zip.Writer, dir string, file string) error {
+	in, err := os.Open(filepath.Join(dir, filepath.FromSlash(file)))
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = file
+	header.Method = zip.Deflate
+
+	w, err := writer.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, in)
+	return err
+}
+
+// Replaces the ancillary files of the options that are directories with temporary archives.
+// The returned cleanup removes the archives and is never nil.
+func packageAncillaryFiles(options *ProgramOptions) (func(), error) {
+	cleanups := make([]func(), 0)
+	cleanup := func() {
+		for _, c := range cleanups {
+			c()
+		}
+	}
+
+	patterns := ArchivePatterns{Include: options.ArchiveInclude, Exclude: options.ArchiveExclude}
+	fields := map[string]*string{
+		"MobilityFile":  &options.MobilityFile,
+		"WebxPanelFile": &options.WebxPanelFile,
+		"ProjectFile":   &options.ProjectFile,
+		"CwsFile":       &options.CwsFile,
+	}
+
+	for _, format := range archiveFormats {
+		file := fields[format.Field]
+		if len(*file) == 0 {
+			continue
+		}
+		if info, err := os.Stat(*file); err != nil || !info.IsDir() {
+			continue
+		}
+
+		archive, remove, err := PackageDirectory(*file, format, patterns)
+		if err != nil {
+			cleanup()
+			return func() {}, err
+		}
+		cleanups = append(cleanups, remove)
+		*file = archive
+	}
+	return cleanup, nil
+}
@@ -0,0 +1,301 @@
+package vc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The outcome of an audited operation.
+const (
+	AuditOk     = "ok"
+	AuditFailed = "failed"
+	AuditError  = "error"
+)
+
+// A mutating request written to the audit log, one JSON object per line.
+type AuditEntry struct {
+	Time      time.Time `json:"time"`
+	User      string    `json:"user"`
+	Profile   string    `json:"profile,omitempty"`
+	Host      string    `json:"host"`
+	Operation string    `json:"operation"`
+	// The rooms, programs, or tokens the operation changed.
+	Targets []string          `json:"targets,omitempty"`
+	Params  map[string]string `json:"params,omitempty"`
+	Files   []RecordedFile    `json:"files,omitempty"`
+	// ok when the appliance accepted the request, failed when it was rejected, error when no response was received.
+	Outcome    string `json:"outcome"`
+	Status     int    `json:"status,omitempty"`
+	StatusInfo string `json:"status_info,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// An append only JSON lines file receiving every mutating request, entries are also forwarded to syslog when configured.
+type AuditLog struct {
+	File    string
+	Profile string
+
+	user   string
+	syslog *SyslogWriter
+	mu     sync.Mutex
+}
+
+// Form fields containing secrets are redacted before the entry is written.
+var secretField = regexp.MustCompile(`(?i)token|password|secret|key`)
+
+// Fields identifying the target of the operation, the remaining fields are written as params.
+var targetFields = []string{"ProgramInstanceId", "ProgramId", "Token"}
+
+// Opens the audit log, the file is created when it doesn't exist so an unwritable path fails before any request is sent.
+func NewAuditLog(file string, profile string, syslog *SyslogWriter) (*AuditLog, error) {
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("FAILED OPENING AUDIT LOG %s: %w", file, err)
+	}
+	f.Close()
+
+	name := "unknown"
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	return &AuditLog{File: file, Profile: profile, user: name, syslog: syslog}, nil
+}
+
+// Writes every mutating request sent by the VC to the audit log, GET requests are never written.
+// Apply the option after the retry option so a retried request is written once.
+func WithAudit(log *AuditLog) VcOptsFunc {
+	return func(v *VC) {
+		v.client.Transport = &auditTransport{
+			transport: v.client.Transport,
+			baseUrl:   v.url,
+			host:      auditHost(v),
+			log:       log,
+		}
+	}
+}
+
+func auditHost(v *VC) string {
+	if len(v.hostname) > 0 {
+		return v.hostname
+	}
+	return "localhost"
+}
+
+type auditTransport struct {
+	transport http.RoundTripper
+	baseUrl   string
+	host      string
+	log       *AuditLog
+}
+
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodGet {
+		return t.transport.RoundTrip(req)
+	}
+
+	recorded, err := recordRequest(req, t.baseUrl)
+	if err != nil {
+		return nil, err
+	}
+	entry := newAuditEntry(recorded)
+	entry.Host = t.host
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		entry.Outcome = AuditError
+		entry.Error = err.Error()
+		return nil, t.write(entry, err)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	entry.Status = resp.StatusCode
+	entry.Outcome = AuditOk
+	if resp.StatusCode != http.StatusOK {
+		entry.Outcome = AuditFailed
+	}
+
+	actions := ActionResponse[any]{}
+	if json.Unmarshal(body, &actions) == nil && len(actions.Actions) > 0 && len(actions.Actions[0].Results) > 0 {
+		result := actions.Actions[0].Results[0]
+		entry.StatusInfo = result.StatusInfo
+		if result.StatusID != 0 {
+			entry.Outcome = AuditFailed
+		}
+	}
+
+	if err := t.write(entry, nil); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Writes the entry, a request that was sent but couldn't be audited is reported as an error.
+func (t *auditTransport) write(entry AuditEntry, requestErr error) error {
+	if err := t.log.Write(entry); err != nil {
+		return fmt.Errorf("THE REQUEST WAS SENT BUT THE AUDIT LOG COULDN'T BE WRITTEN: %w", err)
+	}
+	return requestErr
+}
+
+// Writes the entry to the file and forwards it to syslog, the user and profile of the log are added to the entry.
+// The file is the record of the operation, forwarding to syslog is best effort and never fails the write.
+func (l *AuditLog) Write(entry AuditEntry) error {
+	entry.User = l.user
+	entry.Profile = l.Profile
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.OpenFile(l.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	if l.syslog != nil {
+		l.syslog.Forward(entry.Outcome != AuditOk, line)
+	}
+	return nil
+}
+
+// Waits up to the timeout for the entries queued for syslog, the error reports the entries that weren't forwarded.
+func (l *AuditLog) Flush(timeout time.Duration) error {
+	if l.syslog == nil {
+		return nil
+	}
+	return l.syslog.Flush(timeout)
+}
+
+// Names the operation from the request and separates the targets from the params.
+func newAuditEntry(req RecordedRequest) AuditEntry {
+	entry := AuditEntry{
+		Time:      time.Now().UTC(),
+		Operation: auditOperation(req),
+		Targets:   make([]string, 0),
+		Params:    map[string]string{},
+		Files:     req.Files,
+	}
+
+	resource, id, _ := strings.Cut(req.Path, "/")
+	if len(id) > 0 {
+		if resource == TOKENREQUEST {
+			id = RedactSecret(id)
+		}
+		entry.Targets = append(entry.Targets, id)
+	}
+
+	for field, values := range req.Form {
+		value := strings.Join(values, ",")
+		if secretField.MatchString(field) {
+			value = RedactSecret(value)
+		}
+		if isTargetField(field) {
+			entry.Targets = append(entry.Targets, value)
+			continue
+		}
+		if len(value) > 0 {
+			entry.Params[field] = value
+		}
+	}
+	return entry
+}
+
+func isTargetField(field string) bool {
+	for _, f := range targetFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the VirtualControl method that sent the request, StopRoom or EditProgram.
+func auditOperation(req RecordedRequest) string {
+	resource, _, _ := strings.Cut(req.Path, "/")
+
+	names := map[string]string{PROGRAMINSTANCES: "Room", PROGRAMLIBRARY: "Program", TOKENREQUEST: "Token"}
+	noun, ok := names[resource]
+	if !ok {
+		return req.Method + " " + req.Path
+	}
+
+	switch req.Method {
+	case http.MethodPost:
+		return "Create" + noun
+	case http.MethodDelete:
+		return "Delete" + noun
+	}
+
+	// Room actions are url encoded forms with the action as the only field besides the room.
+	if resource == PROGRAMINSTANCES && len(req.Files) == 0 {
+		for _, action := range []string{"Start", "Stop", "Restart"} {
+			if _, ok := req.Form[action]; ok {
+				return action + noun
+			}
+		}
+		if _, ok := req.Form["DebuggingEnabled"]; ok {
+			return "DebugRoom"
+		}
+	}
+	return "Edit" + noun
+}
+
+// Hides all but the last 4 characters of a secret so entries can still be matched to a token.
+func RedactSecret(secret string) string {
+	if len(secret) <= 8 {
+		return "****"
+	}
+	return "****" + secret[len(secret)-4:]
+}
+
+// Reads every entry of the audit log, lines that can't be decoded are skipped.
+func ReadAuditLog(file string) ([]AuditEntry, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries := make([]AuditEntry, 0)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		entry := AuditEntry{}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
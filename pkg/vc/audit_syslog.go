@@ -0,0 +1,149 @@
+package vc
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// Forwards audit entries to a syslog collector as RFC 5424 messages from the local0 facility.
+// log/syslog isn't available on windows, the messages are written directly to the connection.
+type SyslogWriter struct {
+	network  string
+	address  string
+	hostname string
+
+	conn net.Conn
+	mu   sync.Mutex
+
+	// Messages waiting for the background forwarder.
+	queue   chan syslogMessage
+	pending sync.WaitGroup
+
+	// The messages that couldn't be forwarded and the last reason.
+	failed  int
+	lastErr error
+	failMu  sync.Mutex
+}
+
+type syslogMessage struct {
+	warning bool
+	message []byte
+}
+
+const (
+	syslogLocal0  = 16
+	syslogWarning = 4
+	syslogInfo    = 6
+
+	// Messages are dropped once the queue is full so a slow collector never holds up a request.
+	syslogQueueSize = 256
+)
+
+// Creates a writer for an endpoint such as udp://10.0.0.5:514 or tcp://logs:6514, the port defaults to 514.
+func NewSyslogWriter(endpoint string) (*SyslogWriter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "udp" && u.Scheme != "tcp") || len(u.Hostname()) == 0 {
+		return nil, fmt.Errorf("INVALID SYSLOG ENDPOINT %s, USE udp://HOST:PORT OR tcp://HOST:PORT", endpoint)
+	}
+
+	address := u.Host
+	if len(u.Port()) == 0 {
+		address = net.JoinHostPort(u.Hostname(), "514")
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "-"
+	}
+	w := &SyslogWriter{network: u.Scheme, address: address, hostname: hostname, queue: make(chan syslogMessage, syslogQueueSize)}
+	go w.forward()
+	return w, nil
+}
+
+// Queues the message for the background forwarder, the message is dropped and counted as failed when the queue is full.
+func (w *SyslogWriter) Forward(warning bool, message []byte) {
+	w.pending.Add(1)
+	select {
+	case w.queue <- syslogMessage{warning: warning, message: message}:
+	default:
+		w.pending.Done()
+		w.fail(errors.New("THE SYSLOG QUEUE IS FULL"))
+	}
+}
+
+func (w *SyslogWriter) forward() {
+	for m := range w.queue {
+		if err := w.Write(m.warning, m.message); err != nil {
+			w.fail(err)
+		}
+		w.pending.Done()
+	}
+}
+
+func (w *SyslogWriter) fail(err error) {
+	w.failMu.Lock()
+	defer w.failMu.Unlock()
+	w.failed++
+	w.lastErr = err
+}
+
+// Waits up to the timeout for the queued messages to be sent.
+// The error reports the messages that were dropped, failed, or are still queued once the timeout elapses.
+func (w *SyslogWriter) Flush(timeout time.Duration) error {
+	done := make(chan struct{})
+	go func() {
+		w.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		return fmt.Errorf("%d AUDIT ENTRIES WERE NOT FORWARDED TO SYSLOG %s BEFORE EXITING", len(w.queue)+1, w.address)
+	}
+
+	w.failMu.Lock()
+	defer w.failMu.Unlock()
+	if w.failed > 0 {
+		return fmt.Errorf("%d AUDIT ENTRIES WERE NOT FORWARDED TO SYSLOG %s: %w", w.failed, w.address, w.lastErr)
+	}
+	return nil
+}
+
+// Sends the message, failed operations are sent with the warning severity.
+// TCP messages are framed with the octet count, a broken connection is dialed again once.
+func (w *SyslogWriter) Write(warning bool, message []byte) error {
+	severity := syslogInfo
+	if warning {
+		severity = syslogWarning
+	}
+	msg := fmt.Sprintf("<%d>1 %s %s vcli %d - - %s", syslogLocal0*8+severity, time.Now().UTC().Format(time.RFC3339), w.hostname, os.Getpid(), message)
+	if w.network == "tcp" {
+		msg = fmt.Sprintf("%d %s", len(msg), msg)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if w.conn == nil {
+			w.conn, err = net.DialTimeout(w.network, w.address, 5*time.Second)
+			if err != nil {
+				return fmt.Errorf("FAILED CONNECTING TO SYSLOG %s: %w", w.address, err)
+			}
+		}
+		w.conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+		if _, err = w.conn.Write([]byte(msg)); err == nil {
+			return nil
+		}
+		w.conn.Close()
+		w.conn = nil
+	}
+	return fmt.Errorf("FAILED WRITING TO SYSLOG %s: %w", w.address, err)
+}
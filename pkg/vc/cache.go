@@ -0,0 +1,82 @@
+package vc
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Caches the response body of GET requests for the provided time to live.
+// Every view of the TUI shares the same VC so polling views read from the same cache,
+// concurrent requests for the same resource are merged into a single request.
+// The cache is cleared after every mutating request.
+func WithCache(ttl time.Duration) VcOptsFunc {
+	return func(v *VC) {
+		v.cache = &responseCache{
+			ttl:     ttl,
+			entries: make(map[string]cacheEntry),
+		}
+	}
+}
+
+type responseCache struct {
+	ttl        time.Duration
+	entries    map[string]cacheEntry
+	generation int
+	group      singleflight.Group
+	mu         sync.Mutex
+}
+
+type cacheEntry struct {
+	body    []byte
+	expires time.Time
+}
+
+// Returns the cached body for the resource or calls fetch to refresh it.
+// A nil cache always calls fetch.
+func (c *responseCache) get(resource string, fetch func() ([]byte, error)) ([]byte, error) {
+	if c == nil {
+		return fetch()
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[resource]
+	generation := c.generation
+	c.mu.Unlock()
+
+	if ok && time.Now().Before(entry.expires) {
+		return entry.body, nil
+	}
+
+	body, err, _ := c.group.Do(resource, func() (any, error) {
+		body, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+
+		// A mutation completed while this request was in flight, the body may already be stale.
+		c.mu.Lock()
+		if generation == c.generation {
+			c.entries[resource] = cacheEntry{body: body, expires: time.Now().Add(c.ttl)}
+		}
+		c.mu.Unlock()
+		return body, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return body.([]byte), nil
+}
+
+// Removes every cached response, called after any request that changes the state of the appliance.
+func (c *responseCache) invalidate() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]cacheEntry)
+	c.generation++
+}
@@ -0,0 +1,409 @@
+package vc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A cassette is a recording of real HTTP interactions with a VC4 appliance.
+// Cassettes are recorded with the WithRecorder option and played back with the WithReplay option,
+// allowing a session captured from a customer appliance to be reproduced offline.
+type Cassette struct {
+	Recorded     time.Time     `json:"recorded" yaml:"recorded"`
+	Host         string        `json:"host" yaml:"host"`
+	Interactions []Interaction `json:"interactions" yaml:"interactions"`
+}
+
+type Interaction struct {
+	Request  RecordedRequest  `json:"request" yaml:"request"`
+	Response RecordedResponse `json:"response" yaml:"response"`
+}
+
+// The request is stored relative to the API base url so a cassette recorded
+// against a remote appliance can be replayed in local mode and vice versa.
+type RecordedRequest struct {
+	Method string              `json:"method" yaml:"method"`
+	Path   string              `json:"path" yaml:"path"`
+	Form   map[string][]string `json:"form,omitempty" yaml:"form,omitempty"`
+	Files  []RecordedFile      `json:"files,omitempty" yaml:"files,omitempty"`
+}
+
+type RecordedFile struct {
+	Field    string `json:"field" yaml:"field"`
+	FileName string `json:"filename" yaml:"filename"`
+	Size     int64  `json:"size" yaml:"size"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"status" yaml:"status"`
+	Header     http.Header `json:"header,omitempty" yaml:"header,omitempty"`
+	Body       string      `json:"body" yaml:"body"`
+}
+
+// Loads a cassette from disk, files ending with .yaml or .yml are YAML encoded and every other file is JSON encoded.
+func LoadCassette(file string) (*Cassette, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	cassette := &Cassette{}
+	if isYamlCassette(file) {
+		err = yaml.Unmarshal(data, cassette)
+	} else {
+		err = json.Unmarshal(data, cassette)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("INVALID CASSETTE FILE %s: %w", file, err)
+	}
+	return cassette, nil
+}
+
+// Saves the cassette to disk, the entire file is rewritten using the encoding selected by the file extension.
+func (c *Cassette) Save(file string) error {
+	var data []byte
+	var err error
+	if isYamlCassette(file) {
+		data, err = yaml.Marshal(c)
+	} else {
+		data, err = json.MarshalIndent(c, "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0600)
+}
+
+func isYamlCassette(file string) bool {
+	ext := strings.ToLower(filepath.Ext(file))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// Records every request and response made by the VC into the provided cassette file.
+// The cassette is saved after each interaction so an aborted session is never lost.
+func WithRecorder(file string) VcOptsFunc {
+	return func(v *VC) {
+		v.client.Transport = &recorderTransport{
+			transport: v.client.Transport,
+			baseUrl:   v.url,
+			file:      file,
+			cassette: &Cassette{
+				Recorded:     time.Now(),
+				Host:         v.hostname,
+				Interactions: make([]Interaction, 0),
+			},
+		}
+	}
+}
+
+// Replaces the network with the interactions recorded in the cassette.
+// No requests are sent to an appliance when replaying a cassette.
+func WithReplay(cassette *Cassette) VcOptsFunc {
+	return func(v *VC) {
+		v.client.Transport = &replayTransport{
+			baseUrl:  v.url,
+			cassette: cassette,
+			used:     make([]bool, len(cassette.Interactions)),
+		}
+	}
+}
+
+// recorderTransport forwards the request to the underlying transport and stores the interaction.
+type recorderTransport struct {
+	transport http.RoundTripper
+	baseUrl   string
+	file      string
+	cassette  *Cassette
+	mu        sync.Mutex
+}
+
+func (t *recorderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := recordRequest(req, t.baseUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cassette.Interactions = append(t.cassette.Interactions, Interaction{
+		Request: recorded.redacted(),
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     http.Header{"Content-Type": resp.Header.Values("Content-Type")},
+			Body:       redactBody(body),
+		},
+	})
+
+	if err := t.cassette.Save(t.file); err != nil {
+		return nil, fmt.Errorf("FAILED SAVING CASSETTE %s: %w", t.file, err)
+	}
+	return resp, nil
+}
+
+// replayTransport answers requests from a cassette.
+// Interactions are consumed in the order they were recorded, once every match has been used
+// the last match is replayed again so polling views keep working.
+type replayTransport struct {
+	baseUrl  string
+	cassette *Cassette
+	used     []bool
+	mu       sync.Mutex
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := recordRequest(req, t.baseUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	// The cassette only holds redacted requests.
+	recorded = recorded.redacted()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	last := -1
+	for i, interaction := range t.cassette.Interactions {
+		if !interaction.Request.matches(recorded) {
+			continue
+		}
+		last = i
+		if !t.used[i] {
+			t.used[i] = true
+			return interaction.Response.toResponse(req), nil
+		}
+	}
+
+	if last < 0 {
+		return nil, fmt.Errorf("NO RECORDED INTERACTION FOR %s %s", recorded.Method, recorded.Path)
+	}
+	return t.cassette.Interactions[last].Response.toResponse(req), nil
+}
+
+// Returns a copy of the request with the tokens and other secrets redacted, the token in Token/<token> paths and secret form fields.
+func (r RecordedRequest) redacted() RecordedRequest {
+	resource, id, found := strings.Cut(r.Path, "/")
+	if found && resource == TOKENREQUEST {
+		r.Path = resource + "/" + RedactSecret(id)
+	}
+
+	form := make(map[string][]string, len(r.Form))
+	for field, values := range r.Form {
+		if secretField.MatchString(field) {
+			redacted := make([]string, len(values))
+			for i, v := range values {
+				redacted[i] = RedactSecret(v)
+			}
+			values = redacted
+		}
+		form[field] = values
+	}
+	if r.Form != nil {
+		r.Form = form
+	}
+	return r
+}
+
+// Redacts the secret string values of a JSON response, the tokens returned by GetTokens and CreateToken.
+// Bodies that aren't JSON or don't contain secrets are stored unchanged.
+func redactBody(body []byte) string {
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return string(body)
+	}
+	if !redactSecrets(value) {
+		return string(body)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return string(body)
+	}
+	return string(data)
+}
+
+// Replaces the string values of secret fields at any depth, returns true when a value was redacted.
+func redactSecrets(value any) bool {
+	redacted := false
+	switch v := value.(type) {
+	case map[string]any:
+		for field, child := range v {
+			if s, ok := child.(string); ok && secretField.MatchString(field) {
+				v[field] = RedactSecret(s)
+				redacted = true
+				continue
+			}
+			redacted = redactSecrets(child) || redacted
+		}
+	case []any:
+		for _, child := range v {
+			redacted = redactSecrets(child) || redacted
+		}
+	}
+	return redacted
+}
+
+// Requests match on the method, path, and form fields, the uploaded files aren't compared.
+// The provenance stamp in the Notes field holds the upload time and is left out, so a recorded upload can be replayed.
+func (r RecordedRequest) matches(other RecordedRequest) bool {
+	if r.Method != other.Method || r.Path != other.Path {
+		return false
+	}
+	if len(r.Form) == 0 && len(other.Form) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(matchedForm(r.Form), matchedForm(other.Form))
+}
+
+// Returns a copy of the form with the provenance stamp removed from the notes.
+func matchedForm(form map[string][]string) map[string][]string {
+	matched := make(map[string][]string, len(form))
+	for key, values := range form {
+		if key == "Notes" {
+			stripped := make([]string, 0, len(values))
+			for _, v := range values {
+				stripped = append(stripped, StripProvenance(v))
+			}
+			values = stripped
+		}
+		matched[key] = values
+	}
+	return matched
+}
+
+func (r RecordedResponse) toResponse(req *http.Request) *http.Response {
+	header := r.Header
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// Captures the method, path, form fields and files of a request.
+// The request body is read and replaced so the request can still be sent.
+func recordRequest(req *http.Request, baseUrl string) (RecordedRequest, error) {
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   relativePath(req.URL, baseUrl),
+	}
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return recorded, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return recorded, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	form, files, err := parseRequestBody(req.Header.Get("Content-Type"), body)
+	if err != nil {
+		return recorded, err
+	}
+	recorded.Form = form
+	recorded.Files = files
+	return recorded, nil
+}
+
+// Returns the path of the URL with the API base removed, "ProgramInstance" or "IpTableByPID/ROOM1".
+func relativePath(u *url.URL, baseUrl string) string {
+	path := u.Path
+	if base, err := url.Parse(baseUrl); err == nil {
+		path = strings.TrimPrefix(path, base.Path)
+	}
+	path = strings.TrimPrefix(path, "/")
+	if len(u.RawQuery) > 0 {
+		path += "?" + u.RawQuery
+	}
+	return path
+}
+
+// Decodes a request body into form fields and files.
+// Multipart forms, url encoded forms, and flat JSON objects are supported.
+func parseRequestBody(contentType string, body []byte) (map[string][]string, []RecordedFile, error) {
+	form := map[string][]string{}
+	files := make([]RecordedFile, 0)
+
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case mediaType == "multipart/form-data":
+		reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return form, files, err
+			}
+
+			data, err := io.ReadAll(part)
+			if err != nil {
+				return form, files, err
+			}
+
+			if len(part.FileName()) > 0 {
+				files = append(files, RecordedFile{Field: part.FormName(), FileName: part.FileName(), Size: int64(len(data))})
+				continue
+			}
+			form[part.FormName()] = append(form[part.FormName()], string(data))
+		}
+
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return form, files, err
+		}
+		form = values
+
+	default:
+		fields := map[string]any{}
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return form, files, nil
+		}
+		for k, v := range fields {
+			form[k] = []string{fmt.Sprint(v)}
+		}
+	}
+
+	return form, files, nil
+}
@@ -0,0 +1,63 @@
+package vc
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// Refuses connections to an appliance presenting a certificate with another SHA-256 fingerprint.
+// VC4 appliances use self signed certificates, pinning the fingerprint replaces the chain verification skipped by remote clients.
+// The option has no effect on local clients.
+func WithPinnedCert(fingerprint string) VcOptsFunc {
+	return func(v *VC) {
+		if v.tls == nil {
+			return
+		}
+		expected := normalizeFingerprint(fingerprint)
+		v.tls.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return fmt.Errorf("THE APPLIANCE DID NOT PRESENT A CERTIFICATE")
+			}
+			if actual := Fingerprint(rawCerts[0]); normalizeFingerprint(actual) != expected {
+				return fmt.Errorf("CERTIFICATE FINGERPRINT %s DOES NOT MATCH THE PINNED FINGERPRINT %s", actual, fingerprint)
+			}
+			return nil
+		}
+	}
+}
+
+// Returns the SHA-256 fingerprint of the certificate presented by the host on port 443, AB:CD:...
+func CertFingerprint(host string) (string, error) {
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	conn, err := tls.DialWithDialer(dialer, "tcp", net.JoinHostPort(host, "443"), &tls.Config{InsecureSkipVerify: true})
+	if err != nil {
+		return "", fmt.Errorf("FAILED READING THE CERTIFICATE OF %s: %w", host, err)
+	}
+	defer conn.Close()
+
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return "", fmt.Errorf("%s DID NOT PRESENT A CERTIFICATE", host)
+	}
+	return Fingerprint(certs[0].Raw), nil
+}
+
+// Returns the SHA-256 fingerprint of the DER encoded certificate as colon separated hex, AB:CD:...
+func Fingerprint(der []byte) string {
+	sum := sha256.Sum256(der)
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// Fingerprints are compared without separators or case, openssl and browsers format them differently.
+func normalizeFingerprint(fingerprint string) string {
+	return strings.ToUpper(strings.NewReplacer(":", "", " ", "", "-", "").Replace(fingerprint))
+}
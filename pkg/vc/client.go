@@ -47,9 +47,9 @@ func createLocalClient() *http.Client {
 }
 
 // Creates a remote client with SSL and auth header
-func createRemoteClient(token string) *http.Client {
+func createRemoteClient(token string, tlsConfig *tls.Config) *http.Client {
 	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		TLSClientConfig: tlsConfig,
 	}
 
 	client := &http.Client{
@@ -85,27 +85,38 @@ func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 
 func (vc *VC) getBody(url string, result any) (err VirtualControlError) {
 
+	body, err := vc.cache.get(url, func() ([]byte, error) {
+		return vc.getBytes(url)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return NewServerError(200, err)
+	}
+
+	return nil
+}
+
+func (vc *VC) getBytes(url string) ([]byte, error) {
+
 	resp, err := vc.client.Get(vc.url + url)
 	if err != nil {
-		return NewServerError(500, err)
+		return nil, NewServerError(500, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return NewServerError(resp.StatusCode, errors.New("FFAILED GET REQUEST FROM SERVER"))
+		return nil, NewServerError(resp.StatusCode, errors.New("FFAILED GET REQUEST FROM SERVER"))
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return NewServerError(resp.StatusCode, err)
+		return nil, NewServerError(resp.StatusCode, err)
 	}
-
-	err = json.Unmarshal(body, &result)
-	if err != nil {
-		return NewServerError(resp.StatusCode, err)
-	}
-
-	return nil
+	return body, nil
 }
 
 func addFormField(writer *multipart.Writer, key string, value string) {
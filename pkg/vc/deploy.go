@@ -0,0 +1,187 @@
+package vc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// What happens to the previous program once every room has been moved to the new build.
+type RetireAction string
+
+const (
+	RetireDelete  RetireAction = "delete"
+	RetireArchive RetireAction = "archive"
+	RetireKeep    RetireAction = "keep"
+
+	// Archived programs are tagged in their notes, "#archived".
+	ArchivedTag = "archived"
+)
+
+// The time between room status checks while waiting for a room.
+var statusPollInterval = time.Second
+
+// Deploys a new build as a separate program entry and moves a single canary room onto it.
+// Once the canary is running and healthy the remaining rooms are moved and the old program is retired.
+// This avoids editing the program with StartNow, which restarts every room using the program at once.
+type CanaryDeploy struct {
+	From   ProgramEntry
+	To     ProgramEntry
+	Canary Room
+	// The rooms moved once the canary has been promoted.
+	Remaining Rooms
+	// The devices connected to the canary before it was moved.
+	Baseline RoomImpact
+}
+
+// Selects the canary room from the rooms using the program and records the devices connected to it.
+// When no canary is provided the first running room is used, the canary must be running.
+func NewCanaryDeploy(v VirtualControl, from ProgramEntry, canary string) (*CanaryDeploy, error) {
+	rooms, err := v.GetRooms()
+	if err != nil {
+		return nil, err
+	}
+
+	bound := rooms.ForProgram(from.ProgramID)
+	if len(bound) == 0 {
+		return nil, fmt.Errorf("NO ROOMS ARE USING %s", from.FriendlyName)
+	}
+
+	deploy := &CanaryDeploy{From: from, Remaining: make(Rooms, 0, len(bound))}
+	found := false
+	for _, r := range bound {
+		if !found && (r.ID == canary || (len(canary) == 0 && r.Status == string(Running))) {
+			deploy.Canary = r
+			found = true
+			continue
+		}
+		deploy.Remaining = append(deploy.Remaining, r)
+	}
+
+	if !found && len(canary) > 0 {
+		return nil, fmt.Errorf("CANARY ROOM %s IS NOT USING %s", canary, from.FriendlyName)
+	}
+	if !found {
+		return nil, fmt.Errorf("NO RUNNING ROOMS ARE USING %s, A RUNNING CANARY ROOM IS REQUIRED", from.FriendlyName)
+	}
+	if deploy.Canary.Status != string(Running) {
+		return nil, fmt.Errorf("CANARY ROOM %s MUST BE RUNNING, ROOM IS %s", deploy.Canary.ID, strings.ToUpper(deploy.Canary.Status))
+	}
+
+	deploy.Baseline = newRoomImpact(v, deploy.Canary)
+	return deploy, nil
+}
+
+// Uploads the new build as a separate program entry.
+// ErrDryRun is returned when the upload was captured in dry run mode, the new program doesn't exist.
+func (d *CanaryDeploy) Upload(v VirtualControl, options ProgramOptions) error {
+	result, err := v.CreateProgram(options)
+	if err != nil {
+		return err
+	}
+	if result.Result == DryRunStatus {
+		return ErrDryRun
+	}
+
+	programs, err := v.GetPrograms()
+	if err != nil {
+		return err
+	}
+	to, ok := programs.Find(fmt.Sprint(result.ProgramID))
+	if !ok {
+		return fmt.Errorf("UPLOADED PROGRAM %d NOT FOUND IN THE PROGRAM LIBRARY", result.ProgramID)
+	}
+	d.To = to
+	return nil
+}
+
+// Moves the canary room to the new program and restarts it.
+func (d *CanaryDeploy) MoveCanary(v VirtualControl) RebindResult {
+	return RebindRoom(v, d.Canary, d.To.ProgramID)
+}
+
+// Waits for the canary room to report it is running.
+func (d *CanaryDeploy) WaitForCanary(v VirtualControl, timeout time.Duration) (Room, error) {
+	return WaitForRoomStatus(v, d.Canary.ID, Running, timeout)
+}
+
+// Checks the canary is running and at least as many devices are online as before it was moved.
+func (d *CanaryDeploy) CheckHealth(v VirtualControl) (RoomImpact, error) {
+	rooms, err := v.GetRooms()
+	if err != nil {
+		return RoomImpact{}, err
+	}
+
+	found, _ := rooms.WithIDs([]string{d.Canary.ID})
+	if len(found) == 0 {
+		return RoomImpact{}, fmt.Errorf("CANARY ROOM %s NOT FOUND", d.Canary.ID)
+	}
+
+	health := newRoomImpact(v, found[0])
+	if health.Room.Status != string(Running) {
+		return health, fmt.Errorf("CANARY ROOM %s IS %s", d.Canary.ID, strings.ToUpper(health.Room.Status))
+	}
+	if health.Err == nil && d.Baseline.Err == nil && health.OnlineDevices < d.Baseline.OnlineDevices {
+		return health, fmt.Errorf("CANARY ROOM %s HAS %d DEVICES ONLINE, %d WERE ONLINE BEFORE THE DEPLOY", d.Canary.ID, health.OnlineDevices, d.Baseline.OnlineDevices)
+	}
+	return health, nil
+}
+
+// Moves the remaining rooms to the new program one at a time.
+func (d *CanaryDeploy) Promote(v VirtualControl, report func(RebindResult)) []RebindResult {
+	return RebindRooms(v, d.Remaining, d.To.ProgramID, report)
+}
+
+// Moves the canary back to the previous program, the new program entry is kept for troubleshooting.
+func (d *CanaryDeploy) Rollback(v VirtualControl) RebindResult {
+	return RebindRoom(v, d.Canary, d.From.ProgramID)
+}
+
+// Deletes or archives the previous program, archived programs are tagged #archived in the notes.
+func (d *CanaryDeploy) Retire(v VirtualControl, action RetireAction) error {
+	switch action {
+	case RetireKeep:
+		return nil
+
+	case RetireDelete:
+		_, err := v.DeleteProgram(int(d.From.ProgramID))
+		return err
+
+	case RetireArchive:
+		notes := d.From.Notes
+		if !d.From.HasTag(ArchivedTag) {
+			notes = strings.TrimSpace(notes + " #" + ArchivedTag)
+		}
+		_, err := v.EditProgram(ProgramOptions{
+			ProgramId: int(d.From.ProgramID),
+			AppFile:   d.From.AppFile,
+			Name:      d.From.FriendlyName,
+			Notes:     notes,
+		})
+		return err
+	}
+	return fmt.Errorf("UNKNOWN RETIRE ACTION %s", action)
+}
+
+// Polls the room until it reports the status or the timeout elapses.
+func WaitForRoomStatus(v VirtualControl, id string, status RoomStatus, timeout time.Duration) (Room, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		rooms, err := v.GetRooms()
+		if err != nil {
+			return Room{}, err
+		}
+
+		found, _ := rooms.WithIDs([]string{id})
+		if len(found) == 0 {
+			return Room{}, fmt.Errorf("ROOM %s NOT FOUND", id)
+		}
+		if found[0].Status == string(status) {
+			return found[0], nil
+		}
+		if time.Now().After(deadline) {
+			return found[0], fmt.Errorf("ROOM %s DID NOT REACH %s WITHIN %s, ROOM IS %s", id, strings.ToUpper(string(status)), timeout, strings.ToUpper(found[0].Status))
+		}
+		time.Sleep(statusPollInterval)
+	}
+}
@@ -0,0 +1,129 @@
+package vc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// The time a redeployed room is given to leave the running state, rooms that restart faster are treated as restarted.
+var restartGrace = 10 * time.Second
+
+// A development session uploading the program file to the program each time it is rebuilt.
+// The program is edited with StartNow so the dev room is restarted with the new build.
+type DevSession struct {
+	// The full path of the program file, relative paths are not uploaded.
+	File    string
+	Program ProgramEntry
+	Room    Room
+	// The time allowed for the room to return to running after each upload.
+	Timeout time.Duration
+}
+
+// Finds the program and room, the room must be using the program.
+func NewDevSession(v VirtualControl, file string, program string, room string, timeout time.Duration) (*DevSession, error) {
+	if !programIsValid(file) {
+		return nil, fmt.Errorf("INVALID PROGRAM FILE %s, THE FILE MUST BE A .cpz, .lpz, OR .zip", file)
+	}
+	path, err := filepath.Abs(file)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+
+	programs, err := v.GetPrograms()
+	if err != nil {
+		return nil, err
+	}
+	entry, ok := programs.Find(program)
+	if !ok {
+		return nil, fmt.Errorf("PROGRAM %s NOT FOUND", program)
+	}
+
+	rooms, err := v.GetRooms()
+	if err != nil {
+		return nil, err
+	}
+	found, _ := rooms.WithIDs([]string{room})
+	if len(found) == 0 {
+		return nil, fmt.Errorf("ROOM %s NOT FOUND", room)
+	}
+	if found[0].ProgramID != entry.ProgramID {
+		return nil, fmt.Errorf("ROOM %s IS USING %s NOT %s, REBIND THE ROOM BEFORE STARTING THE SESSION", room, found[0].ProgramName, entry.FriendlyName)
+	}
+
+	return &DevSession{File: path, Program: entry, Room: found[0], Timeout: timeout}, nil
+}
+
+// Enables debugging on the dev room when it is not already enabled.
+func (s *DevSession) EnableDebug(v VirtualControl) error {
+	if s.Room.Debugging {
+		return nil
+	}
+	if _, err := v.DebugRoom(s.Room.ID, true); err != nil {
+		return fmt.Errorf("FAILED ENABLING DEBUG ON ROOM %s: %w", s.Room.ID, err)
+	}
+	s.Room.Debugging = true
+	return nil
+}
+
+// Uploads the program file with StartNow and waits for the room to restart and return to running.
+// Every room using the program is restarted, the session is meant for a program used by a single dev room.
+func (s *DevSession) Deploy(v VirtualControl) (Room, error) {
+	_, err := v.EditProgram(ProgramOptions{
+		ProgramId: int(s.Program.ProgramID),
+		AppFile:   s.File,
+		Name:      s.Program.FriendlyName,
+		Notes:     s.Program.Notes,
+		StartNow:  true,
+	})
+	if err != nil {
+		return s.Room, err
+	}
+
+	room, err := waitForRestart(v, s.Room.ID, s.Timeout)
+	if err != nil {
+		return room, err
+	}
+	s.Room = room
+	return room, nil
+}
+
+// Polls the room until it has left the running state and started again.
+// A room that is running when the restart grace has passed is treated as restarted.
+func waitForRestart(v VirtualControl, id string, timeout time.Duration) (Room, error) {
+	start := time.Now()
+	left := false
+
+	for {
+		rooms, err := v.GetRooms()
+		if err != nil {
+			return Room{}, err
+		}
+		found, _ := rooms.WithIDs([]string{id})
+		if len(found) == 0 {
+			return Room{}, fmt.Errorf("ROOM %s NOT FOUND", id)
+		}
+		room := found[0]
+
+		switch room.Status {
+		case string(Aborted):
+			return room, fmt.Errorf("ROOM %s ABORTED AFTER THE UPLOAD", id)
+		case string(Running):
+			if left || time.Since(start) >= restartGrace {
+				return room, nil
+			}
+		default:
+			left = true
+		}
+
+		if time.Since(start) >= timeout {
+			return room, fmt.Errorf("ROOM %s DID NOT RETURN TO RUNNING WITHIN %s, ROOM IS %s", id, timeout, strings.ToUpper(room.Status))
+		}
+		time.Sleep(statusPollInterval)
+	}
+}
@@ -0,0 +1,49 @@
+package vc
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// A single field that differs between two versions of the same options.
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+func (c FieldChange) String() string {
+	return fmt.Sprintf("%s: %q → %q", c.Field, c.Old, c.New)
+}
+
+// Returns true when the change uploads a file, AppFile, UserFile...
+func (c FieldChange) File() bool {
+	return strings.HasSuffix(c.Field, "File") && len(c.New) > 0
+}
+
+// Compares the exported fields of two structs of the same type and returns the fields that changed.
+// Used to review room and program changes before they are sent to the appliance.
+func DiffFields(old any, new any) []FieldChange {
+	changes := make([]FieldChange, 0)
+
+	o := reflect.Indirect(reflect.ValueOf(old))
+	n := reflect.Indirect(reflect.ValueOf(new))
+	if o.Kind() != reflect.Struct || o.Type() != n.Type() {
+		return changes
+	}
+
+	for i := 0; i < o.NumField(); i++ {
+		field := o.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		before := fmt.Sprint(o.Field(i).Interface())
+		after := fmt.Sprint(n.Field(i).Interface())
+		if before != after {
+			changes = append(changes, FieldChange{Field: field.Name, Old: before, New: after})
+		}
+	}
+	return changes
+}
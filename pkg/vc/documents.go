@@ -0,0 +1,159 @@
+package vc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Returned when the edited document is empty, the edit is cancelled.
+var ErrEditCancelled = errors.New("EDIT CANCELLED, THE FILE IS EMPTY")
+
+// The editable fields of a room, rendered as YAML to be edited in a text editor.
+type RoomDocument struct {
+	ID                  string `yaml:"id"`
+	Name                string `yaml:"name"`
+	ProgramID           int    `yaml:"program_id"`
+	Notes               string `yaml:"notes"`
+	Location            string `yaml:"location"`
+	TimeZone            string `yaml:"timezone"`
+	Latitude            string `yaml:"latitude"`
+	Longitude           string `yaml:"longitude"`
+	AddressSetsLocation bool   `yaml:"address_sets_location"`
+	// A local file uploaded to the room, the current user file is not returned by the API.
+	UserFile string `yaml:"user_file"`
+}
+
+// The editable metadata of a program, the program files are changed with the edit form.
+type ProgramDocument struct {
+	Name  string `yaml:"name"`
+	Notes string `yaml:"notes"`
+}
+
+func NewRoomDocument(room Room) RoomDocument {
+	o := NewRoomOptionsFromRoom(room)
+	return RoomDocument{
+		ID:                  o.ProgramInstanceId,
+		Name:                o.Name,
+		ProgramID:           o.ProgramLibraryId,
+		Notes:               o.Notes,
+		Location:            o.Location,
+		TimeZone:            o.TimeZone,
+		Latitude:            o.Latitude,
+		Longitude:           o.Longitude,
+		AddressSetsLocation: o.AddressSetsLocation,
+	}
+}
+
+func NewProgramDocument(program ProgramEntry) ProgramDocument {
+	return ProgramDocument{
+		Name:  program.FriendlyName,
+		Notes: program.Notes,
+	}
+}
+
+// Renders the room as YAML with a header describing how the file is applied.
+func (d RoomDocument) Marshal() ([]byte, error) {
+	header := fmt.Sprintf("# Editing room %s, save and close the editor to apply the changes.\n", d.ID) +
+		"# The id can't be changed, use vcli rooms rename. An empty file cancels the edit.\n"
+	return marshalDocument(header, d)
+}
+
+// Renders the program metadata as YAML with a header describing how the file is applied.
+func (d ProgramDocument) Marshal() ([]byte, error) {
+	header := fmt.Sprintf("# Editing program %s, save and close the editor to apply the changes.\n", d.Name) +
+		"# An empty file cancels the edit.\n"
+	return marshalDocument(header, d)
+}
+
+func marshalDocument(header string, document any) ([]byte, error) {
+	data, err := yaml.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(header), data...), nil
+}
+
+// Parses the edited room, returns ErrEditCancelled when the document is empty.
+func ParseRoomDocument(data []byte) (RoomDocument, error) {
+	document := RoomDocument{}
+	return document, unmarshalDocument(data, &document)
+}
+
+// Parses the edited program, returns ErrEditCancelled when the document is empty.
+func ParseProgramDocument(data []byte) (ProgramDocument, error) {
+	document := ProgramDocument{}
+	return document, unmarshalDocument(data, &document)
+}
+
+func unmarshalDocument(data []byte, document any) error {
+	empty := true
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) > 0 && !strings.HasPrefix(line, "#") {
+			empty = false
+			break
+		}
+	}
+	if empty {
+		return ErrEditCancelled
+	}
+
+	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(document); err != nil {
+		return fmt.Errorf("INVALID YAML: %w", err)
+	}
+	return nil
+}
+
+// Checks the edited room can be applied to the room and returns the options used to edit the room.
+func (d RoomDocument) Options(room Room, programs Programs) (RoomOptions, error) {
+	if d.ID != room.ID {
+		return RoomOptions{}, fmt.Errorf("THE ROOM ID CAN'T BE CHANGED FROM %s TO %s, USE vcli rooms rename", room.ID, d.ID)
+	}
+	if err := ValidateRoomName(d.Name); err != nil {
+		return RoomOptions{}, err
+	}
+	if _, ok := programs.Find(fmt.Sprint(d.ProgramID)); !ok {
+		return RoomOptions{}, fmt.Errorf("PROGRAM %d NOT FOUND", d.ProgramID)
+	}
+
+	o := NewRoomOptionsFromRoom(room)
+	o.Name = d.Name
+	o.ProgramLibraryId = d.ProgramID
+	o.Notes = d.Notes
+	o.Location = d.Location
+	o.TimeZone = d.TimeZone
+	o.Latitude = d.Latitude
+	o.Longitude = d.Longitude
+	o.AddressSetsLocation = d.AddressSetsLocation
+	o.UserFile = d.UserFile
+	return o, nil
+}
+
+// Checks the edited program metadata and returns the options used to edit the program.
+// The program files are not uploaded again.
+func (d ProgramDocument) Options(program ProgramEntry) (ProgramOptions, error) {
+	if err := ValidateProgramName(d.Name); err != nil {
+		return ProgramOptions{}, err
+	}
+	return ProgramOptions{
+		ProgramId: int(program.ProgramID),
+		AppFile:   program.AppFile,
+		Name:      d.Name,
+		Notes:     d.Notes,
+	}, nil
+}
+
+// Returns the options used to edit the program without any changes, compared against the edited options.
+func NewProgramOptionsFromProgram(program ProgramEntry) ProgramOptions {
+	return ProgramOptions{
+		ProgramId: int(program.ProgramID),
+		AppFile:   program.AppFile,
+		Name:      program.FriendlyName,
+		Notes:     program.Notes,
+	}
+}
@@ -0,0 +1,143 @@
+package vc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+)
+
+// The StatusInfo returned for every request captured in dry run mode.
+const DryRunStatus = "DRY RUN, THE REQUEST WAS NOT SENT"
+
+// Returned by operations of many steps once a change is captured in dry run mode, the later steps depend on the change and are not run.
+var ErrDryRun = errors.New(DryRunStatus)
+
+// A mutating request captured instead of being sent to the appliance.
+type DryRunRequest struct {
+	Method string
+	URL    string
+	Form   map[string][]string
+	Files  []RecordedFile
+}
+
+// Sends GET requests to the appliance and captures every other request without sending it.
+// The captured request is passed to the sink and a successful action response is returned to the caller.
+// Apply the option last so captured requests never reach the audit log or the recorder.
+func WithDryRun(sink func(DryRunRequest)) VcOptsFunc {
+	return func(v *VC) {
+		v.client.Transport = &dryRunTransport{
+			transport: v.client.Transport,
+			baseUrl:   v.url,
+			sink:      sink,
+		}
+	}
+}
+
+type dryRunTransport struct {
+	transport http.RoundTripper
+	baseUrl   string
+	sink      func(DryRunRequest)
+}
+
+func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodGet {
+		return t.transport.RoundTrip(req)
+	}
+
+	recorded, err := recordRequest(req, t.baseUrl)
+	if err != nil {
+		return nil, err
+	}
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	t.sink(DryRunRequest{Method: req.Method, URL: redactedURL(req.URL), Form: recorded.Form, Files: recorded.Files})
+
+	body, err := json.Marshal(ActionResponse[map[string]any]{
+		Actions: []ActionData[map[string]any]{{
+			Operation:    req.Method,
+			TargetObject: recorded.Path,
+			Results: []ActionResponseResult[map[string]any]{{
+				Path:       recorded.Path,
+				Object:     map[string]any{},
+				StatusInfo: DryRunStatus,
+			}},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+// Returns the URL with the token of Token/<token> requests redacted, the same way the audit log redacts it.
+func redactedURL(u *url.URL) string {
+	dir, token := path.Split(u.Path)
+	if path.Base(dir) != TOKENREQUEST || len(token) == 0 {
+		return u.String()
+	}
+	redacted := *u
+	redacted.Path = dir + RedactSecret(token)
+	redacted.RawPath = redacted.Path
+	return redacted.String()
+}
+
+// Renders the request on a single line, "PUT http://127.0.0.1:5000/.../ProgramInstance ProgramInstanceId=ROOM1 Stop=true".
+// Fields are sorted, empty fields are left out, and files are listed with their size.
+func (r DryRunRequest) String() string {
+	parts := []string{r.Method, r.URL}
+	parts = append(parts, r.Fields()...)
+	return strings.Join(parts, " ")
+}
+
+// Returns the form fields and files as sorted key=value pairs.
+func (r DryRunRequest) Fields() []string {
+	fields := make([]string, 0, len(r.Form)+len(r.Files))
+	for k, values := range r.Form {
+		value := strings.Join(values, ",")
+		if len(value) == 0 {
+			continue
+		}
+		if secretField.MatchString(k) {
+			value = RedactSecret(value)
+		}
+		if strings.ContainsAny(value, " \t") {
+			value = fmt.Sprintf("%q", value)
+		}
+		fields = append(fields, k+"="+value)
+	}
+	sort.Strings(fields)
+
+	for _, f := range r.Files {
+		fields = append(fields, fmt.Sprintf("%s=%s (%s)", f.Field, f.FileName, formatSize(f.Size)))
+	}
+	return fields
+}
+
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", size)
+}
@@ -0,0 +1,326 @@
+package vc
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The protected actions, used to approve a single action after the operator confirmed it.
+const (
+	ProtectStop          = "stop"
+	ProtectRestart       = "restart"
+	ProtectDelete        = "delete"
+	ProtectEditRoom      = "edit"
+	ProtectEditProgram   = "edit program"
+	ProtectDeleteProgram = "delete program"
+)
+
+// Rooms matching the pattern or tag are protected, an empty field matches nothing.
+type ProtectionRule struct {
+	// Matched against the room ID and name, "LOBBY*" or "*-PROD".
+	Pattern string `json:"pattern,omitempty"`
+	// A tag in the room notes with or without the #, "production".
+	Tag string `json:"tag,omitempty"`
+}
+
+// A daily window during which protected actions are blocked, the window may cross midnight, 22:00 to 06:00.
+type QuietHours struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// The days the window starts on, mon, tue..., every day when empty.
+	Days []string `json:"days,omitempty"`
+}
+
+// The rules deciding which rooms are protected and when protected actions are allowed.
+type Policy struct {
+	Protect    []ProtectionRule
+	QuietHours *QuietHours
+	// Allows protected actions without confirmation, quiet hours still block them.
+	Force bool
+}
+
+// A stop, restart, delete, or program change of a protected room, or an edit or delete of a program used by protected rooms.
+type ProtectedAction struct {
+	Action string
+	// The room ID or program name the operator types to confirm the action.
+	Target string
+	Rooms  []Room
+	// The rules protecting the rooms, "#production" or "LOBBY*".
+	Rules []string
+}
+
+// Returned when a protected action wasn't confirmed or is blocked by quiet hours.
+type ProtectedError struct {
+	ProtectedAction
+	// The end of the quiet hours blocking the action, zero when the action only needs confirmation.
+	QuietUntil time.Time
+}
+
+func (e *ProtectedError) Error() string {
+	rooms := make([]string, 0, len(e.Rooms))
+	for _, r := range e.Rooms {
+		rooms = append(rooms, r.ID)
+	}
+	if !e.QuietUntil.IsZero() {
+		return fmt.Sprintf("%s %s IS BLOCKED UNTIL %s BY QUIET HOURS, %s PROTECTED BY %s",
+			strings.ToUpper(e.Action), e.Target, e.QuietUntil.Format("Mon 15:04"), strings.Join(rooms, ", "), strings.Join(e.Rules, ", "))
+	}
+	return fmt.Sprintf("%s PROTECTED BY %s, TYPE %s TO CONFIRM OR USE --force TO %s %s",
+		strings.Join(rooms, ", "), strings.Join(e.Rules, ", "), e.Target, strings.ToUpper(e.Action), e.Target)
+}
+
+// Returns true when the error was returned because the action is blocked by quiet hours.
+func (e *ProtectedError) Quiet() bool {
+	return !e.QuietUntil.IsZero()
+}
+
+// Returns the rules protecting the room.
+func (p Policy) Protects(room Room) []string {
+	rules := make([]string, 0)
+	for _, rule := range p.Protect {
+		if len(rule.Tag) > 0 && room.HasTag(rule.Tag) {
+			rules = append(rules, "#"+strings.TrimPrefix(strings.ToLower(rule.Tag), "#"))
+		}
+		if len(rule.Pattern) > 0 && (matchesPattern(rule.Pattern, room.ID) || matchesPattern(rule.Pattern, room.Name)) {
+			rules = append(rules, rule.Pattern)
+		}
+	}
+	return rules
+}
+
+func matchesPattern(pattern string, value string) bool {
+	ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(value))
+	return ok
+}
+
+// Returns the end of the quiet hours when the time is inside them.
+func (q *QuietHours) Until(now time.Time) (time.Time, bool) {
+	if q == nil {
+		return time.Time{}, false
+	}
+	start, err1 := time.Parse("15:04", q.Start)
+	end, err2 := time.Parse("15:04", q.End)
+	if err1 != nil || err2 != nil {
+		return time.Time{}, false
+	}
+
+	// The window started today or, when it crosses midnight, yesterday.
+	for _, days := range []int{0, -1} {
+		day := now.AddDate(0, 0, days)
+		from := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, now.Location())
+		to := time.Date(day.Year(), day.Month(), day.Day(), end.Hour(), end.Minute(), 0, 0, now.Location())
+		if !to.After(from) {
+			to = to.AddDate(0, 0, 1)
+		}
+		if q.startsOn(from.Weekday()) && !now.Before(from) && now.Before(to) {
+			return to, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (q *QuietHours) startsOn(day time.Weekday) bool {
+	if len(q.Days) == 0 {
+		return true
+	}
+	name := strings.ToLower(day.String()[:3])
+	return slices.ContainsFunc(q.Days, func(d string) bool {
+		return strings.HasPrefix(strings.ToLower(d), name)
+	})
+}
+
+// Validates the quiet hours, the start and end must be 24 hour times, 22:00.
+func (q *QuietHours) Validate() error {
+	if q == nil {
+		return nil
+	}
+	for _, t := range []string{q.Start, q.End} {
+		if _, err := time.Parse("15:04", t); err != nil {
+			return fmt.Errorf("INVALID QUIET HOURS TIME %s, USE 24 HOUR TIMES SUCH AS 22:00", t)
+		}
+	}
+	return nil
+}
+
+// Enforces the policy on every stop, restart, delete, and program or user file change of a protected room
+// and every edit and delete of a program used by one.
+// The remaining calls are passed to the wrapped VirtualControl unchanged.
+type PolicyVC struct {
+	VirtualControl
+	Policy Policy
+	// Asks the operator to confirm a protected action, when nil unapproved actions return a ProtectedError.
+	Confirm func(ProtectedAction) bool
+
+	approved map[string]bool
+	mu       sync.Mutex
+}
+
+// Wraps the VirtualControl with the policy.
+func NewPolicyVC(v VirtualControl, policy Policy) *PolicyVC {
+	return &PolicyVC{VirtualControl: v, Policy: policy, approved: map[string]bool{}}
+}
+
+// Returns the error the action would fail with, nil when the action is allowed without confirmation.
+// The rooms must be the rooms affected by the action, no requests are sent.
+func (p *PolicyVC) Check(action string, target string, rooms ...Room) *ProtectedError {
+	protected := ProtectedAction{Action: action, Target: target}
+	for _, room := range rooms {
+		rules := p.Policy.Protects(room)
+		if len(rules) == 0 {
+			continue
+		}
+		protected.Rooms = append(protected.Rooms, room)
+		for _, rule := range rules {
+			if !slices.Contains(protected.Rules, rule) {
+				protected.Rules = append(protected.Rules, rule)
+			}
+		}
+	}
+	if len(protected.Rooms) == 0 {
+		return nil
+	}
+
+	if until, quiet := p.Policy.QuietHours.Until(time.Now()); quiet {
+		return &ProtectedError{ProtectedAction: protected, QuietUntil: until}
+	}
+	if p.Policy.Force {
+		return nil
+	}
+
+	p.mu.Lock()
+	approved := p.approved[action+"/"+target]
+	p.mu.Unlock()
+	if approved {
+		return nil
+	}
+	return &ProtectedError{ProtectedAction: protected}
+}
+
+// Allows the next call of the action on the target, used after the operator typed the confirmation.
+func (p *PolicyVC) Approve(action string, target string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.approved[action+"/"+target] = true
+}
+
+// Checks the action against the current rooms, an approval is used by the check.
+func (p *PolicyVC) enforce(action string, target string, affected func(Rooms) Rooms) error {
+	rooms, err := p.VirtualControl.GetRooms()
+	if err != nil {
+		return err
+	}
+
+	protectedErr := p.Check(action, target, affected(rooms)...)
+
+	p.mu.Lock()
+	delete(p.approved, action+"/"+target)
+	p.mu.Unlock()
+
+	if protectedErr == nil {
+		return nil
+	}
+	if !protectedErr.Quiet() && p.Confirm != nil && p.Confirm(protectedErr.ProtectedAction) {
+		return nil
+	}
+	return protectedErr
+}
+
+func (p *PolicyVC) enforceRoom(action string, id string) error {
+	return p.enforce(action, id, func(rooms Rooms) Rooms {
+		found, _ := rooms.WithIDs([]string{id})
+		return found
+	})
+}
+
+// Programs are confirmed by name, the same name typed to confirm a program with running rooms.
+func (p *PolicyVC) enforceProgram(action string, id int) error {
+	programs, err := p.VirtualControl.GetPrograms()
+	if err != nil {
+		return err
+	}
+	return p.enforce(action, ProgramTarget(programs, id), func(rooms Rooms) Rooms {
+		return rooms.ForProgram(int16(id))
+	})
+}
+
+// Returns the name typed to confirm a protected program action, the program ID when the program isn't found.
+func ProgramTarget(programs Programs, id int) string {
+	for _, p := range programs {
+		if int(p.ProgramID) == id {
+			if len(p.FriendlyName) > 0 {
+				return p.FriendlyName
+			}
+			return p.ProgramName
+		}
+	}
+	return fmt.Sprint(id)
+}
+
+func (p *PolicyVC) StopRoom(id string) (bool, VirtualControlError) {
+	if err := p.enforceRoom(ProtectStop, id); err != nil {
+		return false, err
+	}
+	return p.VirtualControl.StopRoom(id)
+}
+
+func (p *PolicyVC) RestartRoom(id string) (bool, VirtualControlError) {
+	if err := p.enforceRoom(ProtectRestart, id); err != nil {
+		return false, err
+	}
+	return p.VirtualControl.RestartRoom(id)
+}
+
+func (p *PolicyVC) DeleteRoom(id string) VirtualControlError {
+	if err := p.enforceRoom(ProtectDelete, id); err != nil {
+		return err
+	}
+	return p.VirtualControl.DeleteRoom(id)
+}
+
+// Changing the program or uploading a user file changes what the room runs, the remaining fields are edited freely.
+func (p *PolicyVC) EditRoom(options RoomOptions) (RoomCreatedResult, VirtualControlError) {
+	rooms, err := p.VirtualControl.GetRooms()
+	if err != nil {
+		return RoomCreatedResult{}, err
+	}
+	found, _ := rooms.WithIDs([]string{options.ProgramInstanceId})
+	if len(found) > 0 && (int(found[0].ProgramID) != options.ProgramLibraryId || len(options.UserFile) > 0) {
+		if err := p.enforceRoom(ProtectEditRoom, options.ProgramInstanceId); err != nil {
+			return RoomCreatedResult{}, err
+		}
+	}
+	return p.VirtualControl.EditRoom(options)
+}
+
+func (p *PolicyVC) EditProgram(options ProgramOptions) (ProgramUploadResult, VirtualControlError) {
+	if err := p.enforceProgram(ProtectEditProgram, options.ProgramId); err != nil {
+		return ProgramUploadResult{}, err
+	}
+	return p.VirtualControl.EditProgram(options)
+}
+
+func (p *PolicyVC) DeleteProgram(id int) (ProgramDeleteResult, VirtualControlError) {
+	if err := p.enforceProgram(ProtectDeleteProgram, id); err != nil {
+		return ProgramDeleteResult{}, err
+	}
+	return p.VirtualControl.DeleteProgram(id)
+}
+
+// Returns the error the action on the rooms would fail with when the VirtualControl enforces a policy.
+func CheckPolicy(v VirtualControl, action string, target string, rooms ...Room) *ProtectedError {
+	if p, ok := v.(*PolicyVC); ok {
+		return p.Check(action, target, rooms...)
+	}
+	return nil
+}
+
+// Approves the next call of the action when the VirtualControl enforces a policy.
+func ApprovePolicy(v VirtualControl, action string, target string) {
+	if p, ok := v.(*PolicyVC); ok {
+		p.Approve(action, target)
+	}
+}
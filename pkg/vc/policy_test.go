@@ -0,0 +1,87 @@
+package vc
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// 2024-01-01 is a monday.
+func quietTime(day int, hour int, minute int) time.Time {
+	return time.Date(2024, 1, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestQuietHoursUntil(t *testing.T) {
+	overnight := &QuietHours{Start: "22:00", End: "06:00"}
+	friday := &QuietHours{Start: "22:00", End: "06:00", Days: []string{"fri"}}
+	office := &QuietHours{Start: "09:00", End: "17:00", Days: []string{"Monday"}}
+
+	tests := []struct {
+		name    string
+		quiet   *QuietHours
+		now     time.Time
+		blocked bool
+		until   time.Time
+	}{
+		{"before the window", overnight, quietTime(1, 21, 59), false, time.Time{}},
+		{"start of the window", overnight, quietTime(1, 22, 0), true, quietTime(2, 6, 0)},
+		{"before midnight", overnight, quietTime(1, 23, 30), true, quietTime(2, 6, 0)},
+		{"after midnight", overnight, quietTime(2, 2, 0), true, quietTime(2, 6, 0)},
+		{"end of the window", overnight, quietTime(2, 6, 0), false, time.Time{}},
+		{"midday", overnight, quietTime(2, 12, 0), false, time.Time{}},
+
+		{"starts on friday", friday, quietTime(5, 23, 0), true, quietTime(6, 6, 0)},
+		{"started on friday", friday, quietTime(6, 1, 0), true, quietTime(6, 6, 0)},
+		{"starts on saturday", friday, quietTime(6, 23, 0), false, time.Time{}},
+		{"started on thursday", friday, quietTime(5, 1, 0), false, time.Time{}},
+
+		{"same day window", office, quietTime(1, 12, 0), true, quietTime(1, 17, 0)},
+		{"same day window on another day", office, quietTime(2, 12, 0), false, time.Time{}},
+
+		{"no quiet hours", nil, quietTime(1, 23, 0), false, time.Time{}},
+		{"invalid times", &QuietHours{Start: "10pm", End: "06:00"}, quietTime(1, 23, 0), false, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			until, quiet := tt.quiet.Until(tt.now)
+			if quiet != tt.blocked || !until.Equal(tt.until) {
+				t.Errorf("Until(%s) = %s, %t, want %s, %t", tt.now.Format("Mon 15:04"), until, quiet, tt.until, tt.blocked)
+			}
+		})
+	}
+}
+
+func TestPolicyProtects(t *testing.T) {
+	policy := Policy{Protect: []ProtectionRule{
+		{Pattern: "LOBBY*"},
+		{Tag: "production"},
+		{Pattern: "*-prod"},
+		{Tag: "#Vip"},
+		{},
+	}}
+
+	tests := []struct {
+		name  string
+		room  Room
+		rules []string
+	}{
+		{"pattern matches the id", Room{ID: "LOBBY1", Name: "Main Lobby"}, []string{"LOBBY*"}},
+		{"pattern matches the name ignoring case", Room{ID: "ROOM1", Name: "lobby east"}, []string{"LOBBY*"}},
+		{"tag in the notes", Room{ID: "ROOM2", Notes: "#Production room"}, []string{"#production"}},
+		{"tag with punctuation", Room{ID: "ROOM3", Notes: "boardroom, #production."}, []string{"#production"}},
+		{"rule tag with a #", Room{ID: "ROOM4", Notes: "#vip"}, []string{"#vip"}},
+		{"tag and pattern", Room{ID: "CONF-PROD", Notes: "#production"}, []string{"#production", "*-prod"}},
+		{"word without a #", Room{ID: "ROOM5", Notes: "production room"}, []string{}},
+		{"different tag", Room{ID: "ROOM6", Notes: "#productions"}, []string{}},
+		{"unprotected room", Room{ID: "ROOM7", Name: "Huddle"}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rules := policy.Protects(tt.room); !slices.Equal(rules, tt.rules) {
+				t.Errorf("Protects(%s) = %v, want %v", tt.room.ID, rules, tt.rules)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package vc
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	// Rooms with this tag in their notes are treated as production rooms, "#production".
+	ProductionTag = "production"
+)
+
+// The rooms affected by deleting or restarting a program.
+type ProgramImpact struct {
+	Program ProgramEntry
+	Rooms   []RoomImpact
+}
+
+// A room bound to the program along with the devices currently connected to the room.
+type RoomImpact struct {
+	Room          Room
+	OnlineDevices int
+	TotalDevices  int
+	// The IP table could not be loaded, the device counts are unknown.
+	Err VirtualControlError
+}
+
+// Loads the rooms bound to the program and the IP table of each room.
+// The IP tables are loaded in parallel, a failed IP table is reported on the room and does not fail the impact.
+func NewProgramImpact(v VirtualControl, program ProgramEntry) (ProgramImpact, VirtualControlError) {
+	rooms, err := v.GetRooms()
+	if err != nil {
+		return ProgramImpact{Program: program}, err
+	}
+
+	bound := rooms.ForProgram(program.ProgramID)
+	impact := ProgramImpact{
+		Program: program,
+		Rooms:   make([]RoomImpact, len(bound)),
+	}
+
+	var wg sync.WaitGroup
+	for i, room := range bound {
+		wg.Add(1)
+		go func(i int, room Room) {
+			defer wg.Done()
+			impact.Rooms[i] = newRoomImpact(v, room)
+		}(i, room)
+	}
+	wg.Wait()
+
+	return impact, nil
+}
+
+func newRoomImpact(v VirtualControl, room Room) RoomImpact {
+	impact := RoomImpact{Room: room}
+
+	entries, err := v.GetIpTable(room.ID)
+	if err != nil {
+		impact.Err = err
+		return impact
+	}
+
+	impact.TotalDevices = len(entries)
+	for _, e := range entries {
+		if strings.EqualFold(e.Status, "ONLINE") {
+			impact.OnlineDevices++
+		}
+	}
+	return impact
+}
+
+// Returns the online and total device count, "1/2", or unknown when the IP table could not be loaded.
+func (i RoomImpact) Devices() string {
+	if i.Err != nil {
+		return "unknown"
+	}
+	return fmt.Sprintf("%d/%d", i.OnlineDevices, i.TotalDevices)
+}
+
+// Returns the number of bound rooms that are running or starting.
+func (i ProgramImpact) Running() int {
+	running := 0
+	for _, r := range i.Rooms {
+		if r.Room.Status == string(Running) || r.Room.Status == string(Starting) {
+			running++
+		}
+	}
+	return running
+}
+
+// Returns the number of bound rooms tagged as production rooms.
+func (i ProgramImpact) Production() int {
+	production := 0
+	for _, r := range i.Rooms {
+		if r.Room.HasTag(ProductionTag) {
+			production++
+		}
+	}
+	return production
+}
+
+// Running and production rooms require the operator to type the program name before the program is changed.
+func (i ProgramImpact) RequiresTypedConfirmation() bool {
+	return i.Running() > 0 || i.Production() > 0
+}
@@ -10,6 +10,10 @@ type ProgramOptions struct {
 	ProjectFile   string
 	CwsFile       string
 	StartNow      bool
+
+	// Patterns applied when an ancillary file is a directory packaged into an archive.
+	ArchiveInclude []string
+	ArchiveExclude []string
 }
 
 type ProgramOptsFunc func(*ProgramOptions)
@@ -0,0 +1,73 @@
+package vc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The number of rooms bound to each program in the library keyed by the program ID.
+type ProgramUsage map[int16]int
+
+// Counts the rooms bound to each program, orphaned rooms are counted against the missing program ID.
+func NewProgramUsage(rooms Rooms) ProgramUsage {
+	usage := make(ProgramUsage)
+	for _, r := range rooms {
+		usage[r.ProgramID]++
+	}
+	return usage
+}
+
+// Returns the programs that no room is using.
+func (u ProgramUsage) Unused(programs Programs) Programs {
+	unused := make(Programs, 0)
+	for _, p := range programs {
+		if u[p.ProgramID] == 0 {
+			unused = append(unused, p)
+		}
+	}
+	return unused
+}
+
+// Returns the rooms bound to the program.
+func (r Rooms) ForProgram(id int16) Rooms {
+	bound := make(Rooms, 0)
+	for _, room := range r {
+		if room.ProgramID == id {
+			bound = append(bound, room)
+		}
+	}
+	return bound
+}
+
+// The time stamp formats returned by the different versions of the VC4 API.
+var programTimeFormats = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"01/02/2006 15:04:05",
+	"1/2/2006 3:04:05 PM",
+	"Jan 2 2006 15:04:05",
+	"Mon Jan 2 15:04:05 2006",
+	"2006-01-02",
+}
+
+// Returns the time the program file was uploaded to the appliance.
+func (p ProgramEntry) UploadedAt() (time.Time, error) {
+	return parseProgramTime(p.AppFileTS)
+}
+
+// Returns the time the program was compiled.
+func (p ProgramEntry) CompiledAt() (time.Time, error) {
+	return parseProgramTime(p.CompileDateTime)
+}
+
+func parseProgramTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, format := range programTimeFormats {
+		if t, err := time.ParseInLocation(format, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("UNKNOWN TIME FORMAT %s", value)
+}
@@ -9,8 +9,6 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
-	"os"
-	"path/filepath"
 	"slices"
 	"strings"
 )
@@ -49,6 +47,7 @@ func (v *VC) GetPrograms() (Programs, VirtualControlError) {
 }
 
 func (v *VC) CreateProgram(options ProgramOptions) (result ProgramUploadResult, err VirtualControlError) {
+	defer v.cache.invalidate()
 	return postProgram(v, options)
 }
 
@@ -62,10 +61,12 @@ func (v *VC) CreateAndRunProgram(progOps *ProgramOptions, roomOps *RoomOptions)
 }
 
 func (v *VC) EditProgram(options ProgramOptions) (result ProgramUploadResult, err VirtualControlError) {
+	defer v.cache.invalidate()
 	return editProgram(v, options)
 }
 
 func (v *VC) DeleteProgram(id int) (result ProgramDeleteResult, err VirtualControlError) {
+	defer v.cache.invalidate()
 	return deleteProgram(v, id)
 }
 
@@ -87,27 +88,23 @@ func postProgram(vc *VC, options ProgramOptions) (result ProgramUploadResult, er
 		return ProgramUploadResult{}, errors.New("INVALID FILE EXTENSION")
 	}
 
-	file, err := os.Open(options.AppFile)
-	if err != nil {
+	if err := vc.verifySignatures(options); err != nil {
 		return ProgramUploadResult{}, err
 	}
-	defer file.Close()
-
-	form := &bytes.Buffer{}
-	writer := multipart.NewWriter(form)
 
-	part, err := writer.CreateFormFile("AppFile", filepath.Base(options.AppFile))
+	options, err = vc.stampProvenance(options)
 	if err != nil {
 		return ProgramUploadResult{}, err
 	}
 
-	_, err = io.Copy(part, file)
+	form := &bytes.Buffer{}
+	writer := multipart.NewWriter(form)
+
+	err = vc.addProgramFile(writer, options.AppFile, "AppFile")
 	if err != nil {
 		return ProgramUploadResult{}, err
 	}
 
-	file.Close()
-
 	addFormField(writer, "filetype", "AppFile")
 	addFormField(writer, "FriendlyName", options.Name)
 	addFormField(writer, "Notes", options.Notes)
@@ -163,23 +160,42 @@ func postProgram(vc *VC, options ProgramOptions) (result ProgramUploadResult, er
 
 func editProgram(vc *VC, options ProgramOptions) (result ProgramUploadResult, err error) {
 
-	form := &bytes.Buffer{}
-	writer := multipart.NewWriter(form)
-	defer writer.Close()
+	if err := vc.verifySignatures(options); err != nil {
+		return ProgramUploadResult{}, err
+	}
 
-	_, err = validateAndCreateFileHeader(writer, options.AppFile, "AppFile", []string{".cpz", ".lpz", ".zip"})
-	_, err = validateAndCreateFileHeader(writer, options.MobilityFile, "MobilityFile", []string{".zip", ".Core3z"})
-	_, err = validateAndCreateFileHeader(writer, options.ProjectFile, "ProjectFile", []string{".zip", "ch5z", ".vtz", ".Core3z"})
-	_, err = validateAndCreateFileHeader(writer, options.WebxPanelFile, "WebxPanelFile", []string{".zip", "ch5z", ".vtz", ".Core3z"})
-	_, err = validateAndCreateFileHeader(writer, options.CwsFile, "CwsFile", []string{".zip"})
+	cleanup, err := packageAncillaryFiles(&options)
+	if err != nil {
+		return ProgramUploadResult{}, err
+	}
+	defer cleanup()
 
+	options, err = vc.stampProvenance(options)
 	if err != nil {
 		return ProgramUploadResult{}, err
 	}
 
-	if len(options.MobilityFile) > 0 {
-		addFormFile(options.MobilityFile, "MobilityFile", writer)
+	form := &bytes.Buffer{}
+	writer := multipart.NewWriter(form)
+	defer writer.Close()
+
+	headers := []struct {
+		file       string
+		key        string
+		extensions []string
+	}{
+		{options.AppFile, "AppFile", []string{".cpz", ".lpz", ".zip"}},
+		{options.MobilityFile, "MobilityFile", []string{".zip", ".Core3z"}},
+		{options.ProjectFile, "ProjectFile", []string{".zip", "ch5z", ".vtz", ".Core3z"}},
+		{options.WebxPanelFile, "WebxPanelFile", []string{".zip", "ch5z", ".vtz", ".Core3z"}},
+		{options.CwsFile, "CwsFile", []string{".zip"}},
+	}
+	for _, h := range headers {
+		if _, err = vc.validateAndCreateFileHeader(writer, h.file, h.key, h.extensions); err != nil {
+			return ProgramUploadResult{}, err
+		}
 	}
+
 	addFormField(writer, "ProgramId", fmt.Sprintf("%d", options.ProgramId))
 	addFormField(writer, "FriendlyName", options.Name)
 	addFormField(writer, "Notes", options.Notes)
@@ -268,6 +284,14 @@ func deleteProgram(vc *VC, id int) (result ProgramDeleteResult, err error) {
 	return NewProgramDeleteResult(&actions), nil
 }
 
+// The program name rule shared by the program forms and the YAML editor.
+func ValidateProgramName(name string) error {
+	if len(name) < 5 {
+		return fmt.Errorf("NAME %s MUST HAVE AT LEAST 5 CHARATERS", name)
+	}
+	return nil
+}
+
 func programIsValid(file string) bool {
 
 	return strings.HasSuffix(file, ".cpz") ||
@@ -279,7 +303,7 @@ func programFileIsFullPath(file string) bool {
 	return strings.ContainsAny(file, "/") || strings.ContainsAny(file, "\\")
 }
 
-func validateAndCreateFileHeader(writer *multipart.Writer, file string, key string, extensions []string) (bool, error) {
+func (vc *VC) validateAndCreateFileHeader(writer *multipart.Writer, file string, key string, extensions []string) (bool, error) {
 	if !programFileIsFullPath(file) {
 		return false, nil
 	}
@@ -288,7 +312,7 @@ func validateAndCreateFileHeader(writer *multipart.Writer, file string, key stri
 		return false, fmt.Errorf("FILE %s HAS INVALID EXTENSION", file)
 	}
 
-	err := addFormFile(file, key, writer)
+	err := vc.addProgramFile(writer, file, key)
 	if err != nil {
 		return false, err
 	}
@@ -342,6 +366,11 @@ type ProgramEntry struct {
 	IncludeDATVersion string `json:"IncludeDatVersion"`
 }
 
+// Returns true when the program notes contain the #tag, tags are not case sensitive.
+func (p ProgramEntry) HasTag(tag string) bool {
+	return hasNoteTag(p.Notes, tag)
+}
+
 type ProgramUploadResult struct {
 	ProgramID    int16
 	FriendlyName string
@@ -0,0 +1,201 @@
+package vc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// The build information written into the notes of an uploaded program.
+// The stamp is appended to the existing notes, "[vcli sha256=... by=user@host at=... version=... commit=... tag=...]".
+type Provenance struct {
+	SHA256   string
+	Uploader string
+	Time     time.Time
+	// The vcli version that uploaded the file.
+	Version string
+	// The git commit and tag of the work tree containing the file, empty when the file isn't in a git work tree.
+	Commit string
+	Tag    string
+}
+
+var provenancePattern = regexp.MustCompile(`\s*\[vcli ([^\]]*)\]`)
+
+// Stamps the notes of uploaded programs with the provenance of the program file.
+// Only uploads of a local program file are stamped, editing the name or notes of a program leaves the notes unchanged.
+func WithProvenance(version string) VcOptsFunc {
+	return func(v *VC) {
+		v.provenance = &version
+	}
+}
+
+// Returns the options with the provenance of the program file added to the notes.
+// A stamp left by a previous upload no longer describes the new file and is removed when provenance is disabled.
+func (v *VC) stampProvenance(options ProgramOptions) (ProgramOptions, error) {
+	if !programFileIsFullPath(options.AppFile) {
+		return options, nil
+	}
+	if v.provenance == nil {
+		if _, ok := ParseProvenance(options.Notes); ok {
+			options.Notes = StripProvenance(options.Notes)
+		}
+		return options, nil
+	}
+
+	p, err := NewProvenance(options.AppFile, *v.provenance)
+	if err != nil {
+		return options, err
+	}
+	options.Notes = StampProvenance(options.Notes, p)
+	return options, nil
+}
+
+// Reads the provenance of the file, the git commit and tag are read when the file is in a git work tree.
+func NewProvenance(file string, version string) (Provenance, error) {
+	sum, err := FileSHA256(file)
+	if err != nil {
+		return Provenance{}, err
+	}
+
+	p := Provenance{
+		SHA256:   sum,
+		Uploader: uploader(),
+		Time:     time.Now().UTC().Truncate(time.Second),
+		Version:  version,
+	}
+	p.Commit, p.Tag = gitRevision(filepath.Dir(file))
+	return p, nil
+}
+
+// Returns the hex encoded SHA-256 of the file.
+func FileSHA256(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func uploader() string {
+	name := "unknown"
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return strings.ReplaceAll(name+"@"+host, " ", "_")
+}
+
+// Returns the short commit and exact tag of the work tree containing the directory.
+func gitRevision(dir string) (commit string, tag string) {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--short", "HEAD").Output()
+	if err != nil {
+		return "", ""
+	}
+	commit = strings.TrimSpace(string(out))
+
+	if out, err := exec.Command("git", "-C", dir, "describe", "--tags", "--exact-match", "HEAD").Output(); err == nil {
+		tag = strings.TrimSpace(string(out))
+	}
+	return commit, tag
+}
+
+// Renders the provenance as the stamp written into the program notes.
+func (p Provenance) String() string {
+	fields := []string{
+		"sha256=" + p.SHA256,
+		"by=" + p.Uploader,
+		"at=" + p.Time.Format(time.RFC3339),
+		"version=" + p.Version,
+	}
+	if len(p.Commit) > 0 {
+		fields = append(fields, "commit="+p.Commit)
+	}
+	if len(p.Tag) > 0 {
+		fields = append(fields, "tag="+p.Tag)
+	}
+	return "[vcli " + strings.Join(fields, " ") + "]"
+}
+
+// Appends the provenance to the notes, a stamp written by a previous upload is replaced.
+func StampProvenance(notes string, p Provenance) string {
+	notes = StripProvenance(notes)
+	if len(notes) == 0 {
+		return p.String()
+	}
+	return notes + " " + p.String()
+}
+
+// Returns the notes without the provenance stamp.
+func StripProvenance(notes string) string {
+	return strings.TrimSpace(provenancePattern.ReplaceAllString(notes, ""))
+}
+
+// Reads the provenance stamp from the notes of a program, false is returned when the notes aren't stamped.
+func ParseProvenance(notes string) (Provenance, bool) {
+	match := provenancePattern.FindStringSubmatch(notes)
+	if match == nil {
+		return Provenance{}, false
+	}
+
+	p := Provenance{}
+	for _, field := range strings.Fields(match[1]) {
+		key, value, _ := strings.Cut(field, "=")
+		switch key {
+		case "sha256":
+			p.SHA256 = value
+		case "by":
+			p.Uploader = value
+		case "at":
+			p.Time, _ = time.Parse(time.RFC3339, value)
+		case "version":
+			p.Version = value
+		case "commit":
+			p.Commit = value
+		case "tag":
+			p.Tag = value
+		}
+	}
+	return p, true
+}
+
+// Returns the provenance stamped into the program notes.
+func (p ProgramEntry) Provenance() (Provenance, bool) {
+	return ParseProvenance(p.Notes)
+}
+
+// Returns the short hash displayed in tables, the first 12 characters.
+func (p Provenance) ShortSHA() string {
+	if len(p.SHA256) > 12 {
+		return p.SHA256[:12]
+	}
+	return p.SHA256
+}
+
+// Returns an error when the SHA-256 of the file doesn't match the provenance.
+func (p Provenance) Verify(file string) error {
+	sum, err := FileSHA256(file)
+	if err != nil {
+		return err
+	}
+	if sum != p.SHA256 {
+		return fmt.Errorf("%s SHA-256 %s DOES NOT MATCH THE UPLOADED FILE %s", file, sum, p.SHA256)
+	}
+	return nil
+}
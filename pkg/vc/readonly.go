@@ -0,0 +1,44 @@
+package vc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Returned for every mutating request sent in read only mode.
+var ErrReadOnly = errors.New("READ ONLY, CHANGES ARE DISABLED")
+
+// Sends GET requests to the appliance and refuses every other request without sending it while enabled returns true.
+// Enabled is checked on each request so the mode can be turned on once the token has been looked up.
+// Apply the option last so refused requests never reach the audit log, the recorder, or the dry run capture.
+func WithReadOnly(enabled func() bool) VcOptsFunc {
+	return func(v *VC) {
+		v.client.Transport = &readOnlyTransport{transport: v.client.Transport, enabled: enabled}
+	}
+}
+
+type readOnlyTransport struct {
+	transport http.RoundTripper
+	enabled   func() bool
+}
+
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodGet || !t.enabled() {
+		return t.transport.RoundTrip(req)
+	}
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, fmt.Errorf("%w, %s %s WAS NOT SENT", ErrReadOnly, req.Method, req.URL.Path)
+}
+
+// Returns the status of the token in the token list, false when the token isn't listed.
+func TokenAccess(tokens []ApiToken, token string) (TokenStatus, bool) {
+	for _, t := range tokens {
+		if t.Token == token {
+			return t.Status, true
+		}
+	}
+	return 0, false
+}
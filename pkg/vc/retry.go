@@ -0,0 +1,184 @@
+package vc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"slices"
+	"syscall"
+	"time"
+)
+
+// The retry policy used by the client transport while the virtualcontrol service is unavailable.
+//
+// - GET requests and room state PUTs are retried on network errors and retryable status codes
+// - every other request is only retried when the connection was refused, the request never reached the server
+// - the wait between attempts grows exponentially with random jitter until the max elapsed time is reached
+type RetryPolicy struct {
+	// Total time spent retrying a single request before the last error is returned.
+	MaxElapsed time.Duration
+	// Time allowed for each individual attempt, this replaces the client timeout.
+	AttemptTimeout time.Duration
+	// Wait before the first retry.
+	InitialInterval time.Duration
+	// Upper limit of the wait between attempts.
+	MaxInterval time.Duration
+	// Growth of the wait after each attempt.
+	Multiplier float64
+	// Randomization of the wait, 0.5 waits anywhere from 50% to 150% of the interval.
+	Jitter float64
+	// Response codes that will cause an idempotent request to be retried.
+	RetryableStatus []int
+}
+
+// The policy used when no values are provided, retries for up to a minute which covers a service restart.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxElapsed:      time.Minute,
+		AttemptTimeout:  5 * time.Second,
+		InitialInterval: 500 * time.Millisecond,
+		MaxInterval:     8 * time.Second,
+		Multiplier:      2,
+		Jitter:          0.5,
+		RetryableStatus: []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+	}
+}
+
+// Retries failed requests using the provided policy.
+// The client timeout is replaced with the per attempt timeout of the policy.
+func WithRetry(policy RetryPolicy) VcOptsFunc {
+	return func(v *VC) {
+		v.client.Transport = &retryTransport{
+			transport: v.client.Transport,
+			policy:    policy,
+		}
+		v.client.Timeout = 0
+	}
+}
+
+type idempotentKey struct{}
+
+// Marks a request as safe to send more than once.
+// Room state changes are idempotent, starting a running room twice leaves it running.
+func markIdempotent(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), idempotentKey{}, true))
+}
+
+func isIdempotent(req *http.Request) bool {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return true
+	}
+	marked, ok := req.Context().Value(idempotentKey{}).(bool)
+	return ok && marked
+}
+
+// retryTransport resends requests to the underlying transport according to the retry policy.
+type retryTransport struct {
+	transport http.RoundTripper
+	policy    RetryPolicy
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	idempotent := isIdempotent(req)
+	interval := t.policy.InitialInterval
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.send(req, attempt)
+
+		retry := false
+		if err != nil {
+			retry = connectionRefused(err) || (idempotent && temporaryError(err))
+		} else if idempotent && slices.Contains(t.policy.RetryableStatus, resp.StatusCode) {
+			retry = true
+		}
+
+		wait := jitter(interval, t.policy.Jitter)
+		if !retry || !canResend(req) || time.Since(start)+wait > t.policy.MaxElapsed {
+			return resp, err
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+
+		interval = time.Duration(math.Min(float64(interval)*t.policy.Multiplier, float64(t.policy.MaxInterval)))
+	}
+}
+
+// Sends a copy of the request limited to the attempt timeout.
+// The timeout is released when the response body is closed.
+func (t *retryTransport) send(req *http.Request, attempt int) (*http.Response, error) {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if t.policy.AttemptTimeout > 0 {
+		ctx, cancel = context.WithTimeout(req.Context(), t.policy.AttemptTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(req.Context())
+	}
+
+	clone := req.Clone(ctx)
+	if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		clone.Body = body
+	}
+
+	resp, err := t.transport.RoundTrip(clone)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// A request with a body can only be sent again when the body can be recreated.
+func canResend(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func connectionRefused(err error) bool {
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
+
+func temporaryError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+func jitter(interval time.Duration, factor float64) time.Duration {
+	if factor <= 0 {
+		return interval
+	}
+	delta := factor * float64(interval)
+	return time.Duration(float64(interval) - delta + rand.Float64()*2*delta)
+}
@@ -1,5 +1,10 @@
 package vc
 
+import (
+	"fmt"
+	"strings"
+)
+
 type RoomOptions struct {
 	Name                string
 	ProgramInstanceId   string
@@ -21,3 +26,46 @@ func NewRoomOptions(programId int, id string, name string) RoomOptions {
 		ProgramInstanceId: id,
 	}
 }
+
+// Creates the options required to edit an existing room, every editable value of the room is preserved.
+func NewRoomOptionsFromRoom(room Room) RoomOptions {
+	return RoomOptions{
+		Name:                room.Name,
+		ProgramInstanceId:   room.ID,
+		ProgramLibraryId:    int(room.ProgramID),
+		Notes:               room.Notes,
+		Location:            room.Location,
+		TimeZone:            room.TimeZone,
+		Latitude:            room.Latitude,
+		Longitude:           room.Longitude,
+		AddressSetsLocation: room.AddressSetsLocation,
+	}
+}
+
+// Creates the options required to create a copy of the room with a new ID and name.
+// The user file can't be downloaded from the API, a local copy of the file must be provided to clone it.
+func NewRoomOptionsFromClone(room Room, id string, name string) RoomOptions {
+	options := NewRoomOptionsFromRoom(room)
+	options.ProgramInstanceId = id
+	options.Name = name
+	return options
+}
+
+// Room IDs are used in URLs and file paths on the appliance, special characters and spaces are not allowed.
+func ValidateRoomId(id string) error {
+	if len(id) == 0 {
+		return fmt.Errorf("ROOM ID IS REQUIRED")
+	}
+	if strings.ContainsAny(id, " !@#$%^&*()_+{}[]|\\<,>.?/") {
+		return fmt.Errorf("ROOM ID CANNOT CONTAIN SPECIAL CHARACTERS OR SPACES %s", id)
+	}
+	return nil
+}
+
+// The room name rule shared by the room forms and bulk creation, checked before any room is created.
+func ValidateRoomName(name string) error {
+	if len(name) < 5 {
+		return fmt.Errorf("NAME %s MUST HAVE AT LEAST 5 CHARATERS", name)
+	}
+	return nil
+}
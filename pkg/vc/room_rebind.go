@@ -0,0 +1,103 @@
+package vc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The time a room is given to stop before it is started again.
+var restartDelay = 3 * time.Second
+
+// The outcome of moving a single room to a different program.
+type RebindResult struct {
+	Room Room
+	// The room was running and has been restarted on the new program.
+	Restarted bool
+	Err       error
+}
+
+// Moves the room to the program, rooms that were running are restarted so the new program is loaded.
+// In dry run mode the room is not restarted and ErrDryRun is returned.
+func RebindRoom(v VirtualControl, room Room, programId int16) RebindResult {
+	options := NewRoomOptionsFromRoom(room)
+	options.ProgramLibraryId = int(programId)
+
+	result, err := v.EditRoom(options)
+	if err != nil {
+		return RebindResult{Room: room, Err: err}
+	}
+	if result.Message == DryRunStatus {
+		return RebindResult{Room: room, Err: ErrDryRun}
+	}
+
+	restarted, err := restartRunningRoom(v, room)
+	if err != nil {
+		return RebindResult{Room: room, Err: fmt.Errorf("REBOUND BUT %w", err)}
+	}
+	return RebindResult{Room: room, Restarted: restarted}
+}
+
+// Restarts the room when it is running, returns false when the room was not running.
+// VC4 ignores the restart action, the room is restarted by stopping and starting it.
+func restartRunningRoom(v VirtualControl, room Room) (bool, error) {
+	if room.Status != string(Running) && room.Status != string(Starting) {
+		return false, nil
+	}
+
+	if _, err := v.StopRoom(room.ID); err != nil {
+		return false, fmt.Errorf("FAILED STOPPING ROOM %s: %w", room.ID, err)
+	}
+	time.Sleep(restartDelay)
+
+	if _, err := v.StartRoom(room.ID); err != nil {
+		return false, fmt.Errorf("FAILED STARTING ROOM %s: %w", room.ID, err)
+	}
+	return true, nil
+}
+
+// Moves each room to the program one at a time, report is called as each room completes.
+func RebindRooms(v VirtualControl, rooms Rooms, programId int16, report func(RebindResult)) []RebindResult {
+	results := make([]RebindResult, 0, len(rooms))
+	for _, room := range rooms {
+		result := RebindRoom(v, room, programId)
+		if report != nil {
+			report(result)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// Finds a program by ID, friendly name, or program name.
+func (p Programs) Find(program string) (ProgramEntry, bool) {
+	for _, entry := range p {
+		if fmt.Sprint(entry.ProgramID) == program ||
+			strings.EqualFold(entry.FriendlyName, program) ||
+			strings.EqualFold(entry.ProgramName, program) {
+			return entry, true
+		}
+	}
+	return ProgramEntry{}, false
+}
+
+// Returns the rooms with the provided IDs, the IDs that don't match a room are returned as missing.
+func (r Rooms) WithIDs(ids []string) (Rooms, []string) {
+	found := make(Rooms, 0, len(ids))
+	missing := make([]string, 0)
+
+	for _, id := range ids {
+		match := false
+		for _, room := range r {
+			if room.ID == id {
+				found = append(found, room)
+				match = true
+				break
+			}
+		}
+		if !match {
+			missing = append(missing, id)
+		}
+	}
+	return found, missing
+}
@@ -0,0 +1,138 @@
+package vc
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// The configuration of a room saved before the room is deleted so it can be recreated.
+type RoomBackup struct {
+	Saved time.Time `json:"saved"`
+	Room  Room      `json:"room"`
+}
+
+// The outcome of renaming a room.
+type RenameResult struct {
+	// The file the original room configuration was saved to.
+	Backup string
+	// The running and debugging state of the original room was restored on the new room.
+	Started   bool
+	Debugging bool
+}
+
+// Returns the directory room backups are saved to, ~/.config/vcli/backups on linux.
+func DefaultBackupDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "vcli", "backups")
+}
+
+// Saves the room configuration to a JSON file in the directory and returns the file name.
+func SaveRoomBackup(dir string, room Room) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+
+	backup := RoomBackup{Saved: time.Now(), Room: room}
+	data, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	file := filepath.Join(dir, fmt.Sprintf("%s-%s.json", room.ID, backup.Saved.Format("20060102-150405")))
+	return file, os.WriteFile(file, data, 0600)
+}
+
+// Loads a room backup saved by SaveRoomBackup.
+func LoadRoomBackup(file string) (RoomBackup, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return RoomBackup{}, err
+	}
+
+	backup := RoomBackup{}
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return RoomBackup{}, fmt.Errorf("INVALID ROOM BACKUP %s: %w", file, err)
+	}
+	return backup, nil
+}
+
+// Recreates the room saved in the backup and restores its running and debugging state.
+// The user file can't be restored, it is not returned by the API.
+func RestoreRoom(v VirtualControl, backup RoomBackup) error {
+	if _, err := v.CreateRoom(NewRoomOptionsFromRoom(backup.Room)); err != nil {
+		return err
+	}
+	_, _, err := restoreRoomState(v, backup.Room, backup.Room.ID)
+	return err
+}
+
+// Changes the ID of a room, VC4 keys rooms by ID so the room is deleted and created again with the new ID.
+//
+// - the room configuration is saved to the backup directory
+// - the room is stopped and deleted
+// - a room is created using the options, the options contain the new ID
+// - the running and debugging state of the original room is restored
+//
+// When the new room can't be created the original room is restored from the backup.
+// In dry run mode ErrDryRun is returned once the new room is captured, the state is not restored.
+// IP table entries are keyed by room ID and are not moved to the new room.
+func RenameRoom(v VirtualControl, room Room, options RoomOptions, backupDir string) (RenameResult, error) {
+	if room.ID == options.ProgramInstanceId {
+		return RenameResult{}, fmt.Errorf("ROOM %s ALREADY HAS THE ID %s", room.ID, options.ProgramInstanceId)
+	}
+
+	backup, err := SaveRoomBackup(backupDir, room)
+	if err != nil {
+		return RenameResult{}, fmt.Errorf("FAILED SAVING BACKUP OF ROOM %s, THE ROOM WAS NOT RENAMED: %w", room.ID, err)
+	}
+	result := RenameResult{Backup: backup}
+
+	if room.Status == string(Running) || room.Status == string(Starting) {
+		if _, err := v.StopRoom(room.ID); err != nil {
+			return result, fmt.Errorf("FAILED STOPPING ROOM %s, THE ROOM WAS NOT RENAMED: %w", room.ID, err)
+		}
+	}
+
+	if err := v.DeleteRoom(room.ID); err != nil {
+		restoreRoomState(v, room, room.ID)
+		return result, fmt.Errorf("FAILED DELETING ROOM %s, THE ROOM WAS NOT RENAMED: %w", room.ID, err)
+	}
+
+	created, err := v.CreateRoom(options)
+	if err != nil {
+		if restoreErr := RestoreRoom(v, RoomBackup{Room: room}); restoreErr != nil {
+			return result, fmt.Errorf("FAILED CREATING ROOM %s: %w\n\nFAILED RESTORING ROOM %s: %v\n\nRESTORE THE ROOM FROM %s", options.ProgramInstanceId, err, room.ID, restoreErr, backup)
+		}
+		return result, fmt.Errorf("FAILED CREATING ROOM %s, ROOM %s WAS RESTORED: %w", options.ProgramInstanceId, room.ID, err)
+	}
+	if created.Message == DryRunStatus {
+		return result, ErrDryRun
+	}
+
+	result.Started, result.Debugging, err = restoreRoomState(v, room, options.ProgramInstanceId)
+	return result, err
+}
+
+// Applies the debugging and running state of the room to the room with the ID.
+func restoreRoomState(v VirtualControl, room Room, id string) (started bool, debugging bool, err error) {
+	if room.Debugging {
+		if _, err := v.DebugRoom(id, true); err != nil {
+			return false, false, fmt.Errorf("FAILED ENABLING DEBUGGING ON ROOM %s: %w", id, err)
+		}
+		debugging = true
+	}
+
+	if room.Status == string(Running) || room.Status == string(Starting) {
+		if _, err := v.StartRoom(id); err != nil {
+			return false, debugging, fmt.Errorf("FAILED STARTING ROOM %s: %w", id, err)
+		}
+		started = true
+	}
+	return started, debugging, nil
+}
@@ -0,0 +1,267 @@
+package vc
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var bracePattern = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// The most values a pattern can expand to, so a typo in a range can't exhaust memory.
+const maxExpansions = 1000
+
+// A value generated from a pattern and the values substituted for each brace.
+type Expansion struct {
+	Value string
+	Parts []string
+}
+
+// Expands the braces in the pattern, ranges "CONF{01..20}" and lists "{A,B}" are supported.
+// Ranges keep the zero padding of the start value, multiple braces produce every combination.
+func ExpandPattern(pattern string) ([]Expansion, error) {
+	match := bracePattern.FindStringSubmatchIndex(pattern)
+	if match == nil {
+		return []Expansion{{Value: pattern, Parts: []string{}}}, nil
+	}
+
+	values, err := expandBrace(pattern[match[2]:match[3]])
+	if err != nil {
+		return nil, err
+	}
+
+	rest, err := ExpandPattern(pattern[match[1]:])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values)*len(rest) > maxExpansions {
+		return nil, fmt.Errorf("PATTERN EXPANDS TO MORE THAN %d VALUES", maxExpansions)
+	}
+
+	expansions := make([]Expansion, 0, len(values)*len(rest))
+	for _, v := range values {
+		for _, r := range rest {
+			expansions = append(expansions, Expansion{
+				Value: pattern[:match[0]] + v + r.Value,
+				Parts: append([]string{v}, r.Parts...),
+			})
+		}
+	}
+	return expansions, nil
+}
+
+func expandBrace(brace string) ([]string, error) {
+	start, end, isRange := strings.Cut(brace, "..")
+	if !isRange {
+		values := strings.Split(brace, ",")
+		if len(values) < 2 {
+			return nil, fmt.Errorf("INVALID PATTERN {%s}, USE A RANGE {1..10} OR A LIST {A,B}", brace)
+		}
+		if len(values) > maxExpansions {
+			return nil, fmt.Errorf("PATTERN {%s} EXPANDS TO MORE THAN %d VALUES", brace, maxExpansions)
+		}
+		return values, nil
+	}
+
+	from, err := strconv.Atoi(start)
+	if err != nil {
+		return nil, fmt.Errorf("INVALID RANGE START %s", start)
+	}
+	to, err := strconv.Atoi(end)
+	if err != nil {
+		return nil, fmt.Errorf("INVALID RANGE END %s", end)
+	}
+	if to < from {
+		return nil, fmt.Errorf("INVALID RANGE {%s}, THE END MUST BE GREATER THAN THE START", brace)
+	}
+	// A negative difference means the subtraction overflowed.
+	if to-from < 0 || to-from >= maxExpansions {
+		return nil, fmt.Errorf("RANGE {%s} EXPANDS TO MORE THAN %d VALUES", brace, maxExpansions)
+	}
+
+	width := 0
+	if len(start) > 1 && strings.HasPrefix(start, "0") {
+		width = len(start)
+	}
+
+	values := make([]string, 0, to-from+1)
+	for i := from; i <= to; i++ {
+		values = append(values, fmt.Sprintf("%0*d", width, i))
+	}
+	return values, nil
+}
+
+// Creates the options for each room ID generated by the ID pattern.
+// The name template replaces {id} with the room ID and {n} with the values substituted into the ID pattern.
+func ExpandRoomTemplate(idPattern string, nameTemplate string, base RoomOptions) ([]RoomOptions, error) {
+	ids, err := ExpandPattern(idPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	options := make([]RoomOptions, 0, len(ids))
+	for _, id := range ids {
+		name := strings.ReplaceAll(nameTemplate, "{id}", id.Value)
+		name = strings.ReplaceAll(name, "{n}", strings.Join(id.Parts, "-"))
+
+		o := base
+		o.ProgramInstanceId = id.Value
+		o.Name = name
+		options = append(options, o)
+	}
+	return options, nil
+}
+
+// The columns of a rooms CSV file, the id column is required.
+var roomColumns = []string{"id", "name", "program", "location", "timezone", "latitude", "longitude", "notes", "userfile"}
+
+// Reads one room per row from a CSV file with a header row.
+// Missing columns and empty values use the default options, the program column accepts a program ID or name.
+func ParseRoomsCSV(r io.Reader, programs Programs, defaults RoomOptions) ([]RoomOptions, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("INVALID ROOMS CSV: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, h := range header {
+		column := strings.ToLower(strings.TrimSpace(h))
+		if !slices.Contains(roomColumns, column) {
+			return nil, fmt.Errorf("UNKNOWN ROOMS CSV COLUMN %s, COLUMNS ARE %s", h, strings.Join(roomColumns, ","))
+		}
+		columns[column] = i
+	}
+	if _, ok := columns["id"]; !ok {
+		return nil, fmt.Errorf("ROOMS CSV IS MISSING THE id COLUMN")
+	}
+
+	options := make([]RoomOptions, 0)
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("INVALID ROOMS CSV LINE %d: %w", line, err)
+		}
+
+		value := func(column string, fallback string) string {
+			if i, ok := columns[column]; ok && i < len(row) && len(strings.TrimSpace(row[i])) > 0 {
+				return strings.TrimSpace(row[i])
+			}
+			return fallback
+		}
+
+		o := defaults
+		o.ProgramInstanceId = value("id", "")
+		o.Name = value("name", o.ProgramInstanceId)
+		o.Location = value("location", o.Location)
+		o.TimeZone = value("timezone", o.TimeZone)
+		o.Latitude = value("latitude", o.Latitude)
+		o.Longitude = value("longitude", o.Longitude)
+		o.Notes = value("notes", o.Notes)
+		o.UserFile = value("userfile", o.UserFile)
+
+		if program := value("program", ""); len(program) > 0 {
+			entry, ok := programs.Find(program)
+			if !ok {
+				return nil, fmt.Errorf("ROOMS CSV LINE %d PROGRAM %s NOT FOUND", line, program)
+			}
+			o.ProgramLibraryId = int(entry.ProgramID)
+		}
+
+		options = append(options, o)
+	}
+	return options, nil
+}
+
+// Checks the rooms can be created, every ID must be valid and unused and every room must have a unique name.
+// Each user file is validated once. All problems are returned so they can be fixed at once.
+func ValidateNewRooms(existing Rooms, options []RoomOptions) []error {
+	errs := make([]error, 0)
+	ids := map[string]bool{}
+	names := map[string]bool{}
+	files := map[string]bool{}
+
+	for _, r := range existing {
+		ids[r.ID] = true
+		names[strings.ToLower(r.Name)] = true
+	}
+
+	for _, o := range options {
+		if err := ValidateRoomId(o.ProgramInstanceId); err != nil {
+			errs = append(errs, err)
+		} else if ids[o.ProgramInstanceId] {
+			errs = append(errs, fmt.Errorf("ROOM %s ALREADY EXISTS", o.ProgramInstanceId))
+		}
+		ids[o.ProgramInstanceId] = true
+
+		if err := ValidateRoomName(o.Name); err != nil {
+			errs = append(errs, fmt.Errorf("ROOM %s %w", o.ProgramInstanceId, err))
+		} else if names[strings.ToLower(o.Name)] {
+			errs = append(errs, fmt.Errorf("ROOM %s NAME %s IS ALREADY USED", o.ProgramInstanceId, o.Name))
+		}
+		names[strings.ToLower(o.Name)] = true
+
+		if o.ProgramLibraryId == 0 {
+			errs = append(errs, fmt.Errorf("ROOM %s HAS NO PROGRAM", o.ProgramInstanceId))
+		}
+
+		if len(o.UserFile) > 0 && !files[o.UserFile] {
+			files[o.UserFile] = true
+			if err := ValidateUserFile(o.UserFile); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
+// The outcome of creating a single room.
+type CreateRoomResult struct {
+	Options RoomOptions
+	Result  RoomCreatedResult
+	Err     error
+}
+
+// Creates the rooms using up to parallel requests at a time, report is called as each room completes.
+// The results are returned in the same order as the options.
+func CreateRooms(v VirtualControl, options []RoomOptions, parallel int, report func(CreateRoomResult)) []CreateRoomResult {
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	results := make([]CreateRoomResult, len(options))
+	limit := make(chan struct{}, parallel)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for i, o := range options {
+		wg.Add(1)
+		go func(i int, o RoomOptions) {
+			defer wg.Done()
+			limit <- struct{}{}
+			defer func() { <-limit }()
+
+			result, err := v.CreateRoom(o)
+			results[i] = CreateRoomResult{Options: o, Result: result, Err: err}
+
+			if report != nil {
+				mu.Lock()
+				report(results[i])
+				mu.Unlock()
+			}
+		}(i, o)
+	}
+	wg.Wait()
+	return results
+}
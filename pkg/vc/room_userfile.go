@@ -0,0 +1,112 @@
+package vc
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// The file types VC4 accepts as a room user file.
+var userFileExtensions = []string{".zip", ".csv", ".json", ".cfg", ".txt"}
+
+// The outcome of uploading a user file to a single room.
+type UserFileResult struct {
+	Room Room
+	// The room was running and has been restarted to load the user file.
+	Restarted bool
+	Err       error
+}
+
+// Checks the user file can be uploaded to a room.
+// JSON and CSV files are parsed so a broken configuration is never uploaded, other file types are only checked by extension.
+func ValidateUserFile(file string) error {
+	if !userFileIsValid(file) {
+		return fmt.Errorf("USER FILE %s MUST BE ONE OF %s", file, strings.Join(userFileExtensions, ", "))
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".json":
+		var value any
+		if err := json.Unmarshal(data, &value); err != nil {
+			var syntax *json.SyntaxError
+			if errors.As(err, &syntax) {
+				line := bytes.Count(data[:syntax.Offset], []byte("\n")) + 1
+				return fmt.Errorf("INVALID JSON IN %s LINE %d: %w", file, line, err)
+			}
+			return fmt.Errorf("INVALID JSON IN %s: %w", file, err)
+		}
+	case ".csv":
+		if _, err := csv.NewReader(bytes.NewReader(data)).ReadAll(); err != nil {
+			return fmt.Errorf("INVALID CSV IN %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// Extensions are matched ignoring case, the same rule is used when validating and uploading the file.
+func userFileIsValid(file string) bool {
+	return validateProgramExtensions(strings.ToLower(file), userFileExtensions)
+}
+
+// Adds the user file to the room form, relative paths are resolved from the working directory.
+func addUserFile(writer *multipart.Writer, file string) error {
+	if len(file) == 0 {
+		return nil
+	}
+	if !userFileIsValid(file) {
+		return fmt.Errorf("USER FILE %s MUST BE ONE OF %s", file, strings.Join(userFileExtensions, ", "))
+	}
+	path, err := filepath.Abs(file)
+	if err != nil {
+		return err
+	}
+	return addFormFile(path, "UserFile", writer)
+}
+
+// Uploads the user file to the room, running rooms are restarted when restart is true so the file is loaded.
+// The file is validated before the room is changed.
+func PushUserFile(v VirtualControl, room Room, file string, restart bool) UserFileResult {
+	if err := ValidateUserFile(file); err != nil {
+		return UserFileResult{Room: room, Err: err}
+	}
+
+	options := NewRoomOptionsFromRoom(room)
+	options.UserFile = file
+	if _, err := v.EditRoom(options); err != nil {
+		return UserFileResult{Room: room, Err: err}
+	}
+
+	if !restart {
+		return UserFileResult{Room: room}
+	}
+
+	restarted, err := restartRunningRoom(v, room)
+	if err != nil {
+		return UserFileResult{Room: room, Err: fmt.Errorf("UPLOADED BUT %w", err)}
+	}
+	return UserFileResult{Room: room, Restarted: restarted}
+}
+
+// Uploads the user file to each room one at a time, report is called as each room completes.
+func PushUserFiles(v VirtualControl, rooms Rooms, file string, restart bool, report func(UserFileResult)) []UserFileResult {
+	results := make([]UserFileResult, 0, len(rooms))
+	for _, room := range rooms {
+		result := PushUserFile(v, room, file, restart)
+		if report != nil {
+			report(result)
+		}
+		results = append(results, result)
+	}
+	return results
+}
@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"slices"
 	"strings"
+	"sync"
 )
 
 const (
@@ -36,14 +37,28 @@ type VcRoomApi interface {
 
 func (v *VC) GetRooms() (Rooms, VirtualControlError) {
 
-	rooms, err := getProgramInstances(v)
-	if err != nil {
-		return make(Rooms, 0), err
+	var rooms ProgramInstanceLibrary
+	var programs ProgramsLibrary
+	var roomsErr, programsErr VirtualControlError
+
+	// The instances and library are independent resources, fetch them at the same time.
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		rooms, roomsErr = getProgramInstances(v)
+	}()
+	go func() {
+		defer wg.Done()
+		programs, programsErr = getProgramLibrary(v)
+	}()
+	wg.Wait()
+
+	if roomsErr != nil {
+		return make(Rooms, 0), roomsErr
 	}
-
-	programs, err := getProgramLibrary(v)
-	if err != nil {
-		return make(Rooms, 0), err
+	if programsErr != nil {
+		return make(Rooms, 0), programsErr
 	}
 
 	return mapRoomsToPrograms(rooms, programs)
@@ -61,7 +76,8 @@ func mapRoomsToPrograms(instances ProgramInstanceLibrary, library ProgramsLibrar
 			roomsModel = append(roomsModel, NewRoom(r, prog))
 			continue
 		}
-		return roomsModel, fmt.Errorf("Room %s has no matching code %s, WTF", r.ProgramInstanceID, r.ProgramInstanceID)
+		// The program was deleted out from under the room, keep the room so it can be rebound or deleted.
+		roomsModel = append(roomsModel, NewOrphanedRoom(r))
 	}
 
 	comparById := func(a, b Room) int {
@@ -74,27 +90,34 @@ func mapRoomsToPrograms(instances ProgramInstanceLibrary, library ProgramsLibrar
 }
 
 func (v *VC) StartRoom(id string) (bool, VirtualControlError) {
+	defer v.cache.invalidate()
 	return putRoomAction(v, id, "Start", true)
 }
 func (v *VC) StopRoom(id string) (bool, VirtualControlError) {
+	defer v.cache.invalidate()
 	return putRoomAction(v, id, "Stop", true)
 }
 func (v *VC) RestartRoom(id string) (bool, VirtualControlError) {
+	defer v.cache.invalidate()
 	return putRoomAction(v, id, "Restart", true)
 }
 func (v *VC) DebugRoom(id string, enable bool) (bool, VirtualControlError) {
+	defer v.cache.invalidate()
 	return putRoomAction(v, id, "DebuggingEnabled", enable)
 }
 
 func (v *VC) CreateRoom(options RoomOptions) (RoomCreatedResult, VirtualControlError) {
+	defer v.cache.invalidate()
 	return postRoom(v, options)
 }
 
 func (v *VC) EditRoom(options RoomOptions) (RoomCreatedResult, VirtualControlError) {
+	defer v.cache.invalidate()
 	return putRoom(v, options)
 }
 
 func (v *VC) DeleteRoom(id string) VirtualControlError {
+	defer v.cache.invalidate()
 	return deleteRoom(v, id)
 }
 
@@ -132,11 +155,11 @@ func putRoomAction(server *VC, id string, action string, state bool) (bool, Virt
 	}
 
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req = markIdempotent(req)
 
 	resp, err := server.client.Do(req)
 	if err != nil {
-
-		return false, NewServerError(resp.StatusCode, err)
+		return false, NewServerError(500, err)
 	}
 	defer resp.Body.Close()
 
@@ -159,6 +182,7 @@ func postRoom(vc *VC, options RoomOptions) (result RoomCreatedResult, err error)
 	}
 	addFormField(writer, "Name", options.Name)
 	addFormField(writer, "ProgramInstanceId", options.ProgramInstanceId)
+	addFormField(writer, "Notes", options.Notes)
 	addFormField(writer, "Location", options.Location)
 	addFormField(writer, "TimeZone", options.TimeZone)
 	addFormField(writer, "Latitude", options.Latitude)
@@ -166,7 +190,7 @@ func postRoom(vc *VC, options RoomOptions) (result RoomCreatedResult, err error)
 	addFormField(writer, "AddressSetsLocation", add)
 	addFormField(writer, "ProgramLibraryId", fmt.Sprintf("%d", options.ProgramLibraryId))
 
-	_, err = validateAndCreateFileHeader(writer, options.UserFile, "UserFile", []string{".zip", ".csv", ".json", ".cfg", ".txt"})
+	err = addUserFile(writer, options.UserFile)
 	if err != nil {
 		return RoomCreatedResult{}, err
 	}
@@ -230,6 +254,7 @@ func putRoom(vc *VC, options RoomOptions) (result RoomCreatedResult, err error)
 	}
 	addFormField(writer, "Name", options.Name)
 	addFormField(writer, "ProgramInstanceId", options.ProgramInstanceId)
+	addFormField(writer, "Notes", options.Notes)
 	addFormField(writer, "Location", options.Location)
 	addFormField(writer, "TimeZone", options.TimeZone)
 	addFormField(writer, "Latitude", options.Latitude)
@@ -237,7 +262,7 @@ func putRoom(vc *VC, options RoomOptions) (result RoomCreatedResult, err error)
 	addFormField(writer, "AddressSetsLocation", add)
 	addFormField(writer, "ProgramLibraryId", fmt.Sprintf("%d", options.ProgramLibraryId))
 
-	_, err = validateAndCreateFileHeader(writer, options.UserFile, "UserFile", []string{".zip", ".csv", ".json", ".cfg", ".txt"})
+	err = addUserFile(writer, options.UserFile)
 	if err != nil {
 		return RoomCreatedResult{}, err
 	}
@@ -360,12 +385,19 @@ type Room struct {
 	ConfigurationLink string
 	XpanelURL         string
 	Notes             string
+	// The name of the user file uploaded to the room, the contents can't be downloaded from the API.
+	UserFile string
+
+	AddressSetsLocation bool
 
 	ProgramID       int16  `json:"ProgramId"`
 	ProgramName     string `json:"ProgramName"`
 	ProgramFriendly string `json:"FriendlyName"`
 	ProgramType     string `json:"ProgramType"`
 	CompileDateTime string `json:"CompileDateTime"`
+
+	// The room references a program that no longer exists in the program library.
+	Orphaned bool
 }
 
 func NewRoom(i ProgramInstance, p ProgramEntry) Room {
@@ -381,6 +413,9 @@ func NewRoom(i ProgramInstance, p ProgramEntry) Room {
 		ConfigurationLink: i.ConfigurationLink,
 		XpanelURL:         i.XpanelURL,
 		Notes:             i.Notes,
+		UserFile:          i.UserFile,
+
+		AddressSetsLocation: i.AddressSetsLocation,
 
 		ProgramID:       p.ProgramID,
 		ProgramFriendly: p.FriendlyName,
@@ -390,6 +425,76 @@ func NewRoom(i ProgramInstance, p ProgramEntry) Room {
 	}
 }
 
+// Returns the #tags found in the room notes without the leading #, "#production #lobby" returns production and lobby.
+func (r Room) Tags() []string {
+	return noteTags(r.Notes)
+}
+
+// Returns true when the room notes contain the #tag, tags are not case sensitive.
+func (r Room) HasTag(tag string) bool {
+	return hasNoteTag(r.Notes, tag)
+}
+
+func noteTags(notes string) []string {
+	tags := make([]string, 0)
+	for _, word := range strings.Fields(notes) {
+		if tag, ok := strings.CutPrefix(word, "#"); ok && len(tag) > 0 {
+			tags = append(tags, strings.ToLower(strings.TrimRight(tag, ".,;:")))
+		}
+	}
+	return tags
+}
+
+func hasNoteTag(notes string, tag string) bool {
+	return slices.Contains(noteTags(notes), strings.ToLower(strings.TrimPrefix(tag, "#")))
+}
+
+// Creates a room for an instance whose program is missing from the program library.
+// The program fields are left empty except the ID the instance is still bound to.
+func NewOrphanedRoom(i ProgramInstance) Room {
+	return Room{
+		ID:                i.ProgramInstanceID,
+		Name:              i.Name,
+		Status:            i.Status,
+		Debugging:         i.DebuggingEnabled,
+		Location:          i.Location,
+		Longitude:         i.Longitude,
+		Latitude:          i.Latitude,
+		TimeZone:          i.TimeZone,
+		ConfigurationLink: i.ConfigurationLink,
+		XpanelURL:         i.XpanelURL,
+		Notes:             i.Notes,
+		UserFile:          i.UserFile,
+
+		AddressSetsLocation: i.AddressSetsLocation,
+
+		ProgramID: int16(i.ProgramLibraryID),
+		Orphaned:  true,
+	}
+}
+
+// Returns the rooms with the status.
+func (r Rooms) WithStatus(status RoomStatus) Rooms {
+	rooms := make(Rooms, 0)
+	for _, room := range r {
+		if room.Status == string(status) {
+			rooms = append(rooms, room)
+		}
+	}
+	return rooms
+}
+
+// Returns the rooms bound to a program that no longer exists.
+func (r Rooms) Orphaned() Rooms {
+	orphans := make(Rooms, 0)
+	for _, room := range r {
+		if room.Orphaned {
+			orphans = append(orphans, room)
+		}
+	}
+	return orphans
+}
+
 type RoomCreatedResult struct {
 	Success bool
 	Message string
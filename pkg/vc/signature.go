@@ -0,0 +1,226 @@
+package vc
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Detached signatures are written next to the signed file, glacialis.cpz.sig.
+const SignatureExtension = ".sig"
+
+// Returned when a file has no detached signature.
+var ErrUnsigned = errors.New("FILE IS NOT SIGNED")
+
+// The public keys trusted to sign program files and whether unsigned files are refused.
+type SignaturePolicy struct {
+	Keys []ed25519.PublicKey
+	// Refuses to upload files without a valid signature, otherwise only files with a signature are verified.
+	Require bool
+}
+
+// Verifies the detached signatures of the program and ancillary files before they are uploaded.
+// A signature that doesn't verify always fails the upload, unsigned files fail when the policy requires signatures.
+func WithSignatures(policy SignaturePolicy) VcOptsFunc {
+	return func(v *VC) {
+		v.signatures = &policy
+	}
+}
+
+// Verifies every local file uploaded by the options before the upload starts, files that aren't full paths are already on the appliance.
+// The files are verified again by addProgramFile as they are added to the upload.
+func (v *VC) verifySignatures(options ProgramOptions) error {
+	if v.signatures == nil {
+		return nil
+	}
+
+	files := []string{options.AppFile, options.MobilityFile, options.ProjectFile, options.WebxPanelFile, options.CwsFile}
+	for _, file := range files {
+		if !programFileIsFullPath(file) {
+			continue
+		}
+		if info, err := os.Stat(file); err == nil && info.IsDir() {
+			if v.signatures.Require {
+				return fmt.Errorf("%s IS A DIRECTORY, PACKAGED ARCHIVES CAN'T BE VERIFIED, PACKAGE AND SIGN THE ARCHIVE BEFORE UPLOADING", file)
+			}
+			continue
+		}
+
+		_, err := VerifyFile(file, v.signatures.Keys)
+		if errors.Is(err, ErrUnsigned) && !v.signatures.Require {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("REFUSING TO UPLOAD %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// Adds the program file to the form, the file is read once and the bytes added to the form are the bytes verified.
+// A file replaced after verifySignatures checked the options is never uploaded.
+func (v *VC) addProgramFile(writer *multipart.Writer, file string, key string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	if v.signatures != nil {
+		_, err := verifyData(file, data, v.signatures.Keys)
+		if err != nil && (v.signatures.Require || !errors.Is(err, ErrUnsigned)) {
+			return fmt.Errorf("REFUSING TO UPLOAD %s: %w", file, err)
+		}
+	}
+
+	part, err := writer.CreateFormFile(key, filepath.Base(file))
+	if err != nil {
+		return err
+	}
+	_, err = part.Write(data)
+	return err
+}
+
+// Generates an ed25519 key pair, the private key is written to the path and the public key to the path with .pub appended.
+func GenerateKey(path string) (ed25519.PublicKey, error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	privateDer, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+	publicDer, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("%s ALREADY EXISTS, REFUSING TO OVERWRITE A SIGNING KEY", path)
+	}
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDer}), 0600); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path+".pub", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDer}), 0644); err != nil {
+		return nil, err
+	}
+	return public, nil
+}
+
+// Loads a PEM encoded ed25519 private key written by GenerateKey.
+func LoadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s IS NOT A PEM ENCODED PRIVATE KEY", path)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("FAILED READING %s: %w", path, err)
+	}
+	private, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s IS NOT AN ED25519 PRIVATE KEY", path)
+	}
+	return private, nil
+}
+
+// Loads a public key from a PEM file or a base64 encoded ed25519 key.
+func LoadPublicKey(value string) (ed25519.PublicKey, error) {
+	if raw, err := base64.StdEncoding.DecodeString(value); err == nil && len(raw) == ed25519.PublicKeySize {
+		return ed25519.PublicKey(raw), nil
+	}
+
+	data, err := os.ReadFile(value)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s IS NOT A PEM ENCODED PUBLIC KEY", value)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("FAILED READING %s: %w", value, err)
+	}
+	public, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s IS NOT AN ED25519 PUBLIC KEY", value)
+	}
+	return public, nil
+}
+
+// Returns a short identifier of the key, the first 8 bytes of the SHA-256 of the key.
+func KeyID(key ed25519.PublicKey) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+// Signs the file and writes the base64 encoded signature next to it, the signature file is returned.
+func SignFile(file string, key ed25519.PrivateKey) (string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	signature := ed25519.Sign(key, data)
+	path := file + SignatureExtension
+	if err := os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(signature)+"\n"), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// Verifies the detached signature of the file against the trusted keys, the key that signed the file is returned.
+// ErrUnsigned is returned when the signature file doesn't exist.
+func VerifyFile(file string, keys []ed25519.PublicKey) (ed25519.PublicKey, error) {
+	if _, err := os.Stat(file + SignatureExtension); errors.Is(err, os.ErrNotExist) {
+		return nil, ErrUnsigned
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return verifyData(file, data, keys)
+}
+
+// Verifies the detached signature of the file against the data read from the file.
+func verifyData(file string, data []byte, keys []ed25519.PublicKey) (ed25519.PublicKey, error) {
+	encoded, err := os.ReadFile(file + SignatureExtension)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrUnsigned
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil || len(signature) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("%s IS NOT A VALID SIGNATURE", file+SignatureExtension)
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("NO TRUSTED KEYS ARE CONFIGURED")
+	}
+
+	for _, key := range keys {
+		if ed25519.Verify(key, data, signature) {
+			return key, nil
+		}
+	}
+	return nil, errors.New("SIGNATURE DOES NOT MATCH ANY TRUSTED KEY, THE FILE WAS MODIFIED OR SIGNED BY AN UNTRUSTED KEY")
+}
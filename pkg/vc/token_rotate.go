@@ -0,0 +1,93 @@
+package vc
+
+import (
+	"fmt"
+)
+
+// The outcome of rotating an API token.
+type RotateResult struct {
+	Old ApiToken
+	New ApiToken
+}
+
+// Replaces the current token with a new token using the same description and status.
+//
+// - the current token is found in the token list of the appliance
+// - a new token is created with the same description and status
+// - the new token is verified by reading the device info with a client connected using the new token
+// - save stores the new token, the profile credentials
+// - the current token is deleted by the client using the new token
+//
+// Every failure rolls back the completed steps, the stored credentials are restored and the new token is deleted.
+// A failed delete is only rolled back when the new token confirms the current token is still listed,
+// otherwise the new token stays saved and the error includes it.
+func RotateToken(v VirtualControl, current string, connect func(token string) (VirtualControl, error), save func(token string) error) (RotateResult, error) {
+	tokens, err := v.GetTokens()
+	if err != nil {
+		return RotateResult{}, fmt.Errorf("FAILED LOADING THE TOKENS: %w", err)
+	}
+
+	result := RotateResult{}
+	found := false
+	for _, t := range tokens {
+		if t.Token == current {
+			result.Old = t
+			found = true
+		}
+	}
+	if !found {
+		return result, fmt.Errorf("TOKEN %s WAS NOT FOUND ON THE APPLIANCE", RedactSecret(current))
+	}
+
+	created, err := v.CreateToken(result.Old.Status == ReadOnlyToken, result.Old.Description)
+	if err != nil {
+		return result, fmt.Errorf("FAILED CREATING THE NEW TOKEN, THE TOKEN WAS NOT ROTATED: %w", err)
+	}
+	if len(created.Token) == 0 {
+		return result, fmt.Errorf("THE APPLIANCE DID NOT RETURN THE NEW TOKEN, THE TOKEN WAS NOT ROTATED")
+	}
+	result.New = created
+
+	// Deletes the new token using the current token, the current token is still valid at every rollback.
+	rollback := func(reason error) error {
+		if _, err := v.DeleteToken(created.Token); err != nil {
+			return fmt.Errorf("%w\n\nFAILED DELETING THE NEW TOKEN %s: %v", reason, RedactSecret(created.Token), err)
+		}
+		return fmt.Errorf("%w, THE NEW TOKEN WAS DELETED", reason)
+	}
+
+	next, err := connect(created.Token)
+	if err != nil {
+		return result, rollback(fmt.Errorf("FAILED CONNECTING WITH THE NEW TOKEN: %w", err))
+	}
+	if _, err := next.DeviceInfo(); err != nil {
+		return result, rollback(fmt.Errorf("THE NEW TOKEN FAILED READING THE DEVICE INFO: %w", err))
+	}
+
+	if err := save(created.Token); err != nil {
+		return result, rollback(fmt.Errorf("FAILED SAVING THE NEW TOKEN: %w", err))
+	}
+
+	// The delete request doesn't report a failure, the token list read with the new token decides the outcome.
+	_, deleteErr := next.DeleteToken(current)
+	tokens, err = next.GetTokens()
+	if err != nil {
+		// The current token may already be deleted, the new token stays saved as it is valid either way.
+		return result, fmt.Errorf("FAILED CONFIRMING THE CURRENT TOKEN WAS DELETED: %w\n\nTHE NEW TOKEN %s IS SAVED, DELETE THE CURRENT TOKEN %s IF IT IS STILL LISTED",
+			err, created.Token, RedactSecret(current))
+	}
+	if _, listed := TokenAccess(tokens, current); !listed {
+		return result, nil
+	}
+
+	// The current token is still valid, restore it and delete the new token.
+	reason := fmt.Errorf("FAILED DELETING THE CURRENT TOKEN, THE TOKEN IS STILL LISTED")
+	if deleteErr != nil {
+		reason = fmt.Errorf("FAILED DELETING THE CURRENT TOKEN: %w", deleteErr)
+	}
+	if saveErr := save(current); saveErr != nil {
+		return result, fmt.Errorf("%w\n\nFAILED RESTORING THE CURRENT TOKEN: %v\n\nTHE NEW TOKEN %s IS SAVED AND BOTH TOKENS ARE VALID",
+			reason, saveErr, created.Token)
+	}
+	return result, rollback(reason)
+}
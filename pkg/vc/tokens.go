@@ -32,6 +32,7 @@ func (v *VC) GetTokens() ([]ApiToken, VirtualControlError) {
 }
 
 func (v *VC) CreateToken(readonly bool, description string) (ApiToken, VirtualControlError) {
+	defer v.cache.invalidate()
 	request := CreateApiTokenRequest{
 		Status:      2,
 		Description: description,
@@ -72,6 +73,7 @@ func (v *VC) CreateToken(readonly bool, description string) (ApiToken, VirtualCo
 }
 
 func (v *VC) EditToken(readonly bool, description string, token string) (ApiToken, VirtualControlError) {
+	defer v.cache.invalidate()
 	request := EditApiTokenRequest{
 		Status:      2,
 		Description: description,
@@ -119,6 +121,7 @@ func (v *VC) EditToken(readonly bool, description string, token string) (ApiToke
 }
 
 func (v *VC) DeleteToken(token string) (bool, VirtualControlError) {
+	defer v.cache.invalidate()
 
 	req, reqErr := http.NewRequest("DELETE", v.url+TOKENREQUEST+"/"+token, nil)
 	if reqErr != nil {
@@ -127,8 +130,9 @@ func (v *VC) DeleteToken(token string) (bool, VirtualControlError) {
 
 	resp, err := v.client.Do(req)
 	if err != nil {
-		return false, NewServerError(resp.StatusCode, err)
+		return false, NewServerError(500, err)
 	}
+	defer resp.Body.Close()
 
 	return true, nil
 }
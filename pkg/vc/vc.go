@@ -1,6 +1,7 @@
 package vc
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net/http"
 )
@@ -41,6 +42,13 @@ type VC struct {
 	port     int
 	hostname string
 	token    string
+	cache    *responseCache
+	// The vcli version written into the provenance of uploaded programs, nil when uploads aren't stamped.
+	provenance *string
+	// The signatures verified before program files are uploaded, nil when signatures aren't verified.
+	signatures *SignaturePolicy
+	// The TLS configuration of a remote client, nil for local clients.
+	tls *tls.Config
 }
 
 type VirtualConfig struct {
@@ -50,9 +58,12 @@ type VirtualConfig struct {
 	token    *string
 }
 
+// Optional configuration applied to the VC after the http client has been created.
+type VcOptsFunc func(*VC)
+
 // Create VC Clients
-func NewLocalVC() VirtualControl {
-	return &VC{
+func NewLocalVC(opts ...VcOptsFunc) VirtualControl {
+	vc := &VC{
 		client:   createLocalClient(),
 		url:      LOCALHOSTURL,
 		http:     true,
@@ -60,17 +71,28 @@ func NewLocalVC() VirtualControl {
 		hostname: "127.0.0.1",
 		token:    "",
 	}
+	return vc.apply(opts)
 }
 
-func NewRemoteVC(host string, token string) VirtualControl {
-	return &VC{
-		client:   createRemoteClient(token),
+func NewRemoteVC(host string, token string, opts ...VcOptsFunc) VirtualControl {
+	tlsConfig := &tls.Config{InsecureSkipVerify: true}
+	vc := &VC{
+		client:   createRemoteClient(token, tlsConfig),
+		tls:      tlsConfig,
 		url:      baseUrl(host),
 		http:     false,
 		port:     5000,
 		hostname: host,
 		token:    token,
 	}
+	return vc.apply(opts)
+}
+
+func (v *VC) apply(opts []VcOptsFunc) *VC {
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
 }
 
 func baseUrl(host string) string {
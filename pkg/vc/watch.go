@@ -0,0 +1,68 @@
+package vc
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// Watches a file by polling its size and modification time.
+// Compilers write the output over several seconds, a change is only reported once the file has stopped changing.
+type FileWatcher struct {
+	File string
+	// The time between checks of the file.
+	Interval time.Duration
+	// The time the size and modification time must stay the same before the change is reported.
+	Settle time.Duration
+
+	last os.FileInfo
+}
+
+// Creates a watcher reporting changes made after the watcher was created.
+func NewFileWatcher(file string, interval time.Duration, settle time.Duration) (*FileWatcher, error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+	return &FileWatcher{File: file, Interval: interval, Settle: settle, last: info}, nil
+}
+
+// Blocks until the file changes and has been stable for the settle time, the new file info is returned.
+// A missing file is treated as a file being written, the watcher keeps waiting for it to return.
+func (w *FileWatcher) Next(ctx context.Context) (os.FileInfo, error) {
+	var candidate os.FileInfo
+	var changed time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(w.Interval):
+		}
+
+		info, err := os.Stat(w.File)
+		if err != nil {
+			candidate = nil
+			continue
+		}
+
+		if candidate == nil || !sameFile(candidate, info) {
+			if sameFile(w.last, info) {
+				candidate = nil
+				continue
+			}
+			candidate = info
+			changed = time.Now()
+			continue
+		}
+
+		if info.Size() > 0 && time.Since(changed) >= w.Settle {
+			w.last = info
+			return info, nil
+		}
+	}
+}
+
+func sameFile(a os.FileInfo, b os.FileInfo) bool {
+	return a.Size() == b.Size() && a.ModTime().Equal(b.ModTime())
+}